@@ -0,0 +1,48 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// apiKeyParam is the query parameter APIKey is sent in
+const apiKeyParam = "api_key"
+
+// apiKeyMiddleware adds APIKey to the query string of each request when set.
+// Transport errors quote the request URL, so the key is masked in them
+// before they can reach logs or diagnostics.
+func (c *Client) apiKeyMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.APIKey == "" {
+			return next(req)
+		}
+
+		query := req.URL.Query()
+		query.Set(apiKeyParam, c.APIKey)
+		req.URL.RawQuery = query.Encode()
+
+		resp, err := next(req)
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = redactAPIKey(urlErr.URL)
+		}
+		return resp, err
+	}
+}
+
+// redactAPIKey masks the api_key parameter of a URL
+func redactAPIKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Don't risk echoing a URL that can't be inspected
+		return "<unparseable URL>"
+	}
+	query := u.Query()
+	if !query.Has(apiKeyParam) {
+		return rawURL
+	}
+	query.Set(apiKeyParam, redactedValue)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPIKeySentAsQueryParameter(t *testing.T) {
+	const key = "k3y&with=special+chars"
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get(apiKeyParam); got != key {
+			t.Errorf("api_key = %q, want %q", got, key)
+		}
+		if got := query.Get("limit"); got != "5" {
+			t.Errorf("limit = %q, want the existing query kept", got)
+		}
+		writeJSON(t, w, http.StatusOK, []Todo{})
+	})
+	c := newTestClient(srv)
+	c.APIKey = key
+
+	if _, err := c.ListTodos(context.Background(), ListOptions{PageSize: 5}); err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+}
+
+func TestAPIKeyRedactedFromTransportErrors(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	c := newTestClient(srv)
+	c.APIKey = "secret-key"
+	c.MaxRetries = 0
+	srv.Close()
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	if err == nil {
+		t.Fatal("GetTodo() succeeded against a closed server")
+	}
+	if strings.Contains(err.Error(), "secret-key") {
+		t.Errorf("error %q contains the API key", err)
+	}
+	if !strings.Contains(err.Error(), apiKeyParam+"="+url.QueryEscape(redactedValue)) {
+		t.Errorf("error %q does not show the masked api_key", err)
+	}
+}
+
+func TestRedactAPIKey(t *testing.T) {
+	tests := map[string]string{
+		"https://api.example.com/todos?api_key=secret&limit=5": "https://api.example.com/todos?api_key=" + url.QueryEscape(redactedValue) + "&limit=5",
+		"https://api.example.com/todos?limit=5":                "https://api.example.com/todos?limit=5",
+		"https://api.example.com/%zz?api_key=secret":           "<unparseable URL>",
+	}
+	for rawURL, want := range tests {
+		if got := redactAPIKey(rawURL); got != want {
+			t.Errorf("redactAPIKey(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
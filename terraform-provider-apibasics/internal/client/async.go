@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// asyncPollInterval is how often a status URL is polled when the server
+// does not send Retry-After
+const asyncPollInterval = time.Second
+
+// asyncStatus is the body of a status resource for an accepted operation
+type asyncStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+
+	// TodoID, or ID, names the todo the operation produced
+	TodoID string `json:"todoId"`
+	ID     string `json:"id"`
+}
+
+// isAccepted reports whether resp is a 202 that should be followed
+func (c *Client) isAccepted(resp *http.Response) bool {
+	return c.FollowAsync && resp.StatusCode == http.StatusAccepted && resp.Header.Get("Location") != ""
+}
+
+// awaitTodo polls the status URL from a 202 Accepted response until the
+// operation finishes, then fetches the resulting todo. id is the todo being
+// updated, or empty for a create, in which case the status must name it.
+func (c *Client) awaitTodo(ctx context.Context, operation, id string, accepted *http.Response) (*Todo, error) {
+	statusPath, err := c.relativePath(accepted.Header.Get("Location"))
+	if err != nil {
+		return nil, err
+	}
+
+	delay := retryAfter(accepted.Header, asyncPollInterval)
+	for attempt := 1; ; attempt++ {
+		tflog.Debug(ctx, "Waiting for asynchronous operation", map[string]any{
+			"operation": operation,
+			"status":    statusPath,
+			"attempt":   attempt,
+			"delay_ms":  delay.Milliseconds(),
+		})
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("%s cancelled while waiting for completion: %w", operation, err)
+		}
+
+		resp, err := c.DoRequestWithOptions(ctx, "GET", statusPath, nil, RequestOptions{Operation: operation + " status"})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusAccepted {
+			delay = retryAfter(resp.Header, asyncPollInterval)
+			resp.Body.Close()
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			return nil, newAPIError(operation+" status", resp)
+		}
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status response: %w", err)
+		}
+
+		var status asyncStatus
+		if err := json.Unmarshal(bodyBytes, &status); err != nil {
+			return nil, fmt.Errorf("failed to decode status response: %w", err)
+		}
+
+		switch strings.ToLower(status.Status) {
+		case "pending", "queued", "running", "processing", "in_progress":
+			delay = retryAfter(resp.Header, asyncPollInterval)
+			continue
+		case "failed", "error", "cancelled":
+			return nil, &APIError{
+				Operation:  operation,
+				StatusCode: resp.StatusCode,
+				Body:       string(bodyBytes),
+				Message:    status.Message,
+			}
+		}
+
+		if id == "" {
+			id = status.TodoID
+		}
+		if id == "" {
+			id = status.ID
+		}
+		if id == "" {
+			return nil, fmt.Errorf("%s completed but the status response did not identify the todo", operation)
+		}
+
+		if err := validateTodoID(id); err != nil {
+			return nil, fmt.Errorf("%s status response: %w", operation, err)
+		}
+
+		// The read cache may still hold the todo from before the operation
+		tflog.Debug(ctx, "Asynchronous operation completed", map[string]any{"operation": operation, "id": id})
+		var todo *Todo
+		err = c.refetchOnDecodeError(ctx, "/todos/"+id, func() (err error) {
+			todo, err = c.fetchTodo(ctx, "/todos/"+id, RequestOptions{})
+			return err
+		})
+		return todo, err
+	}
+}
+
+// relativePath converts a Location header into a path relative to BaseURL,
+// refusing URLs on other hosts so the access token is never sent elsewhere
+func (c *Client) relativePath(location string) (string, error) {
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	ref, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("invalid status URL %q: %w", location, err)
+	}
+
+	resolved := base.ResolveReference(ref).String()
+	rest, ok := strings.CutPrefix(resolved, strings.TrimSuffix(c.BaseURL, "/"))
+	if !ok || !strings.HasPrefix(rest, "/") {
+		return "", fmt.Errorf("status URL %q is outside the API endpoint %s", location, c.BaseURL)
+	}
+	return rest, nil
+}
+
+// retryAfter returns the delay requested by a Retry-After header given in
+// seconds or as an HTTP date, or fallback when there is none. A date in the
+// past means no delay.
+func retryAfter(header http.Header, fallback time.Duration) time.Duration {
+	value := header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return fallback
+}
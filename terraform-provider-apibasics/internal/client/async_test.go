@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// asyncServer accepts creates with a 202 pointing at /status/1, reports
+// the operation pending once and then finished with status, and serves
+// the created todo
+func asyncServer(t *testing.T, final map[string]string) *Client {
+	t.Helper()
+	var polls atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/todos":
+			w.Header().Set("Location", "/status/1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/status/1" && polls.Add(1) == 1:
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/status/1":
+			writeJSON(t, w, http.StatusOK, final)
+		case r.URL.Path == "/todos/"+testTodoID:
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Async"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c := newTestClient(srv)
+	c.FollowAsync = true
+	return c
+}
+
+func TestCreateTodoFollowsAcceptedStatus(t *testing.T) {
+	c := asyncServer(t, map[string]string{"status": "succeeded", "todoId": testTodoID})
+
+	title := "Async"
+	todo, err := c.CreateTodo(context.Background(), TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+	if todo.ID != testTodoID || todo.Title != "Async" {
+		t.Errorf("todo = %+v, want the todo named by the status", todo)
+	}
+}
+
+func TestCreateTodoReportsFailedStatus(t *testing.T) {
+	c := asyncServer(t, map[string]string{"status": "failed", "message": "title rejected"})
+
+	title := "Async"
+	_, err := c.CreateTodo(context.Background(), TodoInput{Title: &title})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "title rejected" {
+		t.Fatalf("CreateTodo() error = %v, want an APIError with the status message", err)
+	}
+}
+
+func TestCreateTodoRejectsInvalidStatusID(t *testing.T) {
+	c := asyncServer(t, map[string]string{"status": "succeeded", "todoId": "../users"})
+
+	title := "Async"
+	if _, err := c.CreateTodo(context.Background(), TodoInput{Title: &title}); !errors.Is(err, ErrInvalidID) {
+		t.Fatalf("CreateTodo() error = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestRelativePathRefusesOtherHosts(t *testing.T) {
+	c := NewClient("https://api.example.com/v1", "", "")
+
+	got, err := c.relativePath("/v1/status/1")
+	if err != nil || got != "/status/1" {
+		t.Errorf("relativePath() = %q, %v, want %q", got, err, "/status/1")
+	}
+	for _, location := range []string{"https://evil.example.com/v1/status/1", "/other/status/1"} {
+		if _, err := c.relativePath(location); err == nil {
+			t.Errorf("relativePath(%q) succeeded, want an error", location)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// authServer issues tokens at the token and refresh paths, and rejects todo
+// requests with a 401 unless they carry the current token
+type authServer struct {
+	mu        sync.Mutex
+	current   string
+	logins    int
+	refreshes int
+
+	// noRefreshToken leaves refresh_token out of token responses, like a
+	// client_credentials grant
+	noRefreshToken bool
+
+	// rejected counts 401 responses. A token request waits until
+	// holdTokens of them have been sent, so that many requests are
+	// rejected at once.
+	rejected   int
+	holdTokens int
+}
+
+func (s *authServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == DefaultTokenPath || r.URL.Path == DefaultRefreshPath {
+			s.awaitRejections()
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch r.URL.Path {
+		case DefaultTokenPath, DefaultRefreshPath:
+			if r.URL.Path == DefaultRefreshPath {
+				s.refreshes++
+			} else {
+				s.logins++
+			}
+			n := s.logins + s.refreshes
+			s.current = "token-" + strconv.Itoa(n)
+			resp := TokenResponse{TokenType: "Bearer", AccessToken: s.current}
+			if !s.noRefreshToken {
+				resp.RefreshToken = "refresh-" + strconv.Itoa(n)
+			}
+			writeJSON(t, w, http.StatusOK, resp)
+		default:
+			if r.Header.Get("Authorization") != "Bearer "+s.current {
+				s.rejected++
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+		}
+	}
+}
+
+// awaitRejections waits, for up to a second, until holdTokens requests
+// have been rejected
+func (s *authServer) awaitRejections() {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		done := s.rejected >= s.holdTokens
+		s.mu.Unlock()
+		if done {
+			return
+		}
+	}
+}
+
+// expire makes the server reject the tokens issued so far
+func (s *authServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = "expired"
+}
+
+func (s *authServer) counts() (logins, refreshes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins, s.refreshes
+}
+
+func TestUnauthorizedWithoutRefreshTokenLogsInAgain(t *testing.T) {
+	s := &authServer{noRefreshToken: true}
+	srv := newTestServer(t, s.handler(t))
+	c := NewClient(srv.URL, "ada@example.com", "secret")
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if c.RefreshToken != "" {
+		t.Fatalf("refresh token = %q, want none from this grant", c.RefreshToken)
+	}
+	s.expire()
+
+	if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if logins, refreshes := s.counts(); logins != 2 || refreshes != 0 {
+		t.Errorf("logins = %d, refreshes = %d, want a second login and no refresh attempt", logins, refreshes)
+	}
+}
+
+func TestRefreshKeepsRefreshTokenWhenNoneReturned(t *testing.T) {
+	s := &authServer{noRefreshToken: true}
+	c := NewClient(newTestServer(t, s.handler(t)).URL, "", "")
+	c.RefreshToken = "long-lived"
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if c.RefreshToken != "long-lived" {
+		t.Errorf("refresh token = %q, want the original kept", c.RefreshToken)
+	}
+	if !strings.HasPrefix(c.accessToken(), "token-") {
+		t.Errorf("access token = %q, want the refreshed one", c.accessToken())
+	}
+}
+
+func TestAuthorizationUsesTokenType(t *testing.T) {
+	tests := []struct {
+		tokenType  string
+		authScheme string
+		want       string
+	}{
+		{tokenType: "", want: "Bearer test-token"},
+		{tokenType: "bearer", want: "Bearer test-token"},
+		{tokenType: "MAC", want: "MAC test-token"},
+		{tokenType: "mac", want: "MAC test-token"},
+		{tokenType: "pop", want: "Pop test-token"},
+		{tokenType: "bearer", authScheme: "Token", want: "Token test-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tokenType+"/"+tt.authScheme, func(t *testing.T) {
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.want {
+					t.Errorf("Authorization = %q, want %q", got, tt.want)
+				}
+				writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+			})
+			c := newTestClient(srv)
+			c.TokenType = tt.tokenType
+			c.AuthScheme = tt.authScheme
+
+			if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+				t.Errorf("GetTodo() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConcurrentUnauthorizedRequestsShareOneRefresh(t *testing.T) {
+	const n = 20
+	s := &authServer{}
+	srv := newTestServer(t, s.handler(t))
+	c := NewClient(srv.URL, "ada@example.com", "secret")
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	s.expire()
+	s.holdTokens = n
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+				t.Errorf("GetTodo() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if logins, refreshes := s.counts(); logins != 1 || refreshes != 1 {
+		t.Errorf("logins = %d, refreshes = %d, want the initial login and a single refresh for %d rejected requests", logins, refreshes, n)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejected != n {
+		t.Errorf("%d requests rejected, want all %d to have been rejected before the refresh", s.rejected, n)
+	}
+}
+
+func TestConcurrentUnauthorizedRequestsShareOneLogin(t *testing.T) {
+	const n = 20
+	s := &authServer{noRefreshToken: true, holdTokens: n}
+	srv := newTestServer(t, s.handler(t))
+	c := NewClient(srv.URL, "ada@example.com", "secret")
+	// A token from an earlier run that the server no longer accepts
+	c.AccessToken = "stale"
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+				t.Errorf("GetTodo() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if logins, refreshes := s.counts(); logins != 1 || refreshes != 0 {
+		t.Errorf("logins = %d, refreshes = %d, want a single login for %d rejected requests", logins, refreshes, n)
+	}
+}
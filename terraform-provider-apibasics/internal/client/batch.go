@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultBatchWindow is how long an update waits for others to join its batch
+const DefaultBatchWindow = 50 * time.Millisecond
+
+// maxBatchSize flushes a batch early once it holds this many updates
+const maxBatchSize = 50
+
+// updateBatcher collects updates submitted within a short window and sends
+// them as one request to the batch endpoint
+type updateBatcher struct {
+	client *Client
+	window time.Duration
+
+	mu      sync.Mutex
+	pending []*batchedUpdate
+	timer   *time.Timer
+
+	// unsupported is set once the API has shown it has no batch endpoint
+	unsupported bool
+}
+
+// batchedUpdate is one caller's update waiting in a batch
+type batchedUpdate struct {
+	ctx  context.Context
+	id   string
+	body map[string]interface{}
+
+	done chan struct{}
+	todo *Todo
+	err  error
+}
+
+// batchRequest is the body sent to the batch endpoint
+type batchRequest struct {
+	Updates []map[string]interface{} `json:"updates"`
+}
+
+// batchResult is the outcome of one update in a batch response
+type batchResult struct {
+	ID    string `json:"id"`
+	Todo  *Todo  `json:"todo"`
+	Error string `json:"error"`
+
+	// Status is the HTTP status the update would have had on its own, when
+	// the API reports it
+	Status int `json:"status"`
+}
+
+// EnableUpdateBatching makes UpdateTodo calls that arrive within window of
+// each other, as they do when Terraform updates many todos in parallel, go
+// to the API as a single POST /todos/batch request.
+//
+// Each caller still blocks until its own update has been applied, so
+// Terraform state stays accurate. The tradeoffs are extra latency of up to
+// window per update, and that a failure of the batch request as a whole
+// fails every update in it, even though the server may have applied some of
+// them; a later refresh reconciles state. If the API has no batch endpoint,
+// updates fall back to being sent individually.
+func (c *Client) EnableUpdateBatching(window time.Duration) {
+	if window <= 0 {
+		window = DefaultBatchWindow
+	}
+	c.updateBatcher = &updateBatcher{client: c, window: window}
+}
+
+// FlushUpdates sends any buffered updates immediately instead of waiting for
+// the batch window to pass
+func (c *Client) FlushUpdates() {
+	if c.updateBatcher != nil {
+		c.updateBatcher.flush()
+	}
+}
+
+// submit queues an update and waits for its batch to be sent
+func (b *updateBatcher) submit(ctx context.Context, id string, body map[string]interface{}) (*Todo, error) {
+	b.mu.Lock()
+	if b.unsupported {
+		b.mu.Unlock()
+		return b.client.updateTodo(ctx, id, body)
+	}
+
+	update := &batchedUpdate{ctx: ctx, id: id, body: body, done: make(chan struct{})}
+	b.pending = append(b.pending, update)
+	if len(b.pending) >= maxBatchSize {
+		batch := b.take()
+		b.mu.Unlock()
+		go b.send(batch)
+	} else {
+		if b.timer == nil {
+			b.timer = time.AfterFunc(b.window, b.flush)
+		}
+		b.mu.Unlock()
+	}
+
+	select {
+	case <-update.done:
+		return update.todo, update.err
+	case <-ctx.Done():
+		// The batch may still apply the update; Terraform will refresh it
+		return nil, ctx.Err()
+	}
+}
+
+// flush sends whatever is pending
+func (b *updateBatcher) flush() {
+	b.mu.Lock()
+	batch := b.take()
+	b.mu.Unlock()
+
+	b.send(batch)
+}
+
+// take removes and returns the pending updates. b.mu must be held.
+func (b *updateBatcher) take() []*batchedUpdate {
+	batch := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return batch
+}
+
+// send delivers a batch and hands each caller its result
+func (b *updateBatcher) send(batch []*batchedUpdate) {
+	if len(batch) == 0 {
+		return
+	}
+
+	defer func() {
+		for _, update := range batch {
+			close(update.done)
+		}
+	}()
+
+	// The batch outlives any one caller, so it must not be cancelled with
+	// the first caller's context, but it keeps its logging fields
+	ctx := context.WithoutCancel(batch[0].ctx)
+
+	if len(batch) == 1 {
+		batch[0].todo, batch[0].err = b.client.updateTodo(batch[0].ctx, batch[0].id, batch[0].body)
+		return
+	}
+
+	results, err := b.post(ctx, batch)
+	if err != nil {
+		for _, update := range batch {
+			update.err = err
+		}
+		return
+	}
+	if results == nil {
+		// No batch endpoint: send the updates one by one from now on
+		b.mu.Lock()
+		b.unsupported = true
+		b.mu.Unlock()
+
+		tflog.Warn(ctx, "API does not support batch updates, sending updates individually")
+		for _, update := range batch {
+			update.todo, update.err = b.client.updateTodo(update.ctx, update.id, update.body)
+		}
+		return
+	}
+
+	byID := make(map[string]batchResult, len(results))
+	for _, result := range results {
+		byID[result.ID] = result
+	}
+	for _, update := range batch {
+		result, ok := byID[update.id]
+		switch {
+		case !ok:
+			update.err = fmt.Errorf("batch update response did not include todo %s", update.id)
+		case result.Error != "":
+			update.err = result.err(update)
+		case result.Todo == nil:
+			update.err = fmt.Errorf("batch update response for todo %s did not include the todo", update.id)
+		default:
+			update.todo = result.Todo
+		}
+	}
+
+	tflog.Debug(ctx, "Sent batched todo updates", map[string]any{"count": len(batch)})
+}
+
+// err converts a failed result into the error updateTodo would have
+// returned for the same status, so a stale or missing todo is reported
+// the same way with batching
+func (r batchResult) err(update *batchedUpdate) error {
+	err := fmt.Errorf("batch update of todo %s failed: %s", update.id, r.Error)
+	switch {
+	case r.Status == http.StatusNotFound:
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case r.Status == http.StatusConflict && update.body["version"] != nil:
+		// Only a versioned update can be stale; other conflicts pass through
+		return fmt.Errorf("%w: %w", ErrTodoChanged, err)
+	}
+	return err
+}
+
+// post sends the batch request. A nil result with a nil error means the API
+// has no batch endpoint.
+func (b *updateBatcher) post(ctx context.Context, batch []*batchedUpdate) ([]batchResult, error) {
+	req := batchRequest{Updates: make([]map[string]interface{}, 0, len(batch))}
+	for _, update := range batch {
+		item := make(map[string]interface{}, len(update.body)+1)
+		for k, v := range update.body {
+			item[k] = v
+		}
+		item["id"] = update.id
+		req.Updates = append(req.Updates, item)
+	}
+
+	// Every item sets fields to fixed values, so the batch is safe to repeat
+	resp, err := b.client.DoRequestWithOptions(ctx, "POST", "/todos/batch", req, RequestOptions{Idempotency: Idempotent, Operation: "batch update todos"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, nil
+	case http.StatusOK, http.StatusMultiStatus:
+	default:
+		return nil, newAPIError("batch update todos", resp)
+	}
+
+	var body struct {
+		Results []batchResult `json:"results"`
+	}
+	if err := b.client.decodeJSON(resp.Body, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if body.Results == nil {
+		body.Results = []batchResult{}
+	}
+
+	return body.Results, nil
+}
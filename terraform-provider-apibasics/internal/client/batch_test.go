@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchResultServer answers POST /todos/batch with result for testTodoID
+// and a successful update of testTodoID2
+func batchResultServer(t *testing.T, result map[string]any) *Client {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/todos/batch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(t, w, http.StatusMultiStatus, map[string]any{"results": []map[string]any{
+			result,
+			{"id": testTodoID2, "todo": map[string]any{"id": testTodoID2, "title": "updated"}},
+		}})
+	})
+	c := newTestClient(srv)
+	c.EnableUpdateBatching(50 * time.Millisecond)
+	return c
+}
+
+// updateBoth updates testTodoID with input and testTodoID2 in the same
+// batch, returning the first update's error
+func updateBoth(t *testing.T, c *Client, input TodoInput) error {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	var err, otherErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err = c.UpdateTodo(context.Background(), testTodoID, input)
+	}()
+	go func() {
+		defer wg.Done()
+		title := "updated"
+		_, otherErr = c.UpdateTodo(context.Background(), testTodoID2, TodoInput{Title: &title})
+	}()
+	wg.Wait()
+
+	if otherErr != nil {
+		t.Errorf("other update in the batch failed: %v", otherErr)
+	}
+	return err
+}
+
+func TestBatchedUpdateConflictIsErrTodoChanged(t *testing.T) {
+	c := batchResultServer(t, map[string]any{"id": testTodoID, "status": http.StatusConflict, "error": "version mismatch"})
+
+	version := 3
+	err := updateBoth(t, c, TodoInput{Version: &version})
+	if !errors.Is(err, ErrTodoChanged) {
+		t.Fatalf("got %v, want ErrTodoChanged", err)
+	}
+}
+
+func TestBatchedUpdateUnversionedConflictIsNotErrTodoChanged(t *testing.T) {
+	c := batchResultServer(t, map[string]any{"id": testTodoID, "status": http.StatusConflict, "error": "conflict"})
+
+	title := "renamed"
+	err := updateBoth(t, c, TodoInput{Title: &title})
+	if err == nil || errors.Is(err, ErrTodoChanged) {
+		t.Fatalf("got %v, want a plain error", err)
+	}
+}
+
+func TestBatchedUpdateMissingTodoIsErrNotFound(t *testing.T) {
+	c := batchResultServer(t, map[string]any{"id": testTodoID, "status": http.StatusNotFound, "error": "todo not found"})
+
+	title := "renamed"
+	err := updateBoth(t, c, TodoInput{Title: &title})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// bulkConcurrency is the number of requests bulk helpers run in parallel
+const bulkConcurrency = 4
+
+// bulkProgressLogInterval is the minimum time between progress log lines
+// from a bulk operation
+const bulkProgressLogInterval = 5 * time.Second
+
+// runBulk calls fn for each index in [0, n) using a bounded pool of workers
+// and returns the error for each index (nil on success). Work that has not
+// started yet is skipped once ctx is cancelled.
+func runBulk(ctx context.Context, n int, fn func(ctx context.Context, i int) error) []error {
+	return runBulkProgress(ctx, n, fn, nil)
+}
+
+// runBulkProgress is runBulk, additionally calling progress with the number
+// of finished items each time one finishes, successfully or not. progress
+// is only ever called from the calling goroutine, so it needs no locking.
+func runBulkProgress(ctx context.Context, n int, fn func(ctx context.Context, i int) error, progress func(done int)) []error {
+	errs := make([]error, n)
+	indexes := make(chan int)
+	finished := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for w := 0; w < bulkConcurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+				} else {
+					errs[i] = fn(ctx, i)
+				}
+				finished <- struct{}{}
+			}
+		}()
+	}
+
+	go func() {
+		for i := 0; i < n; i++ {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(finished)
+	}()
+
+	done := 0
+	for range finished {
+		done++
+		if progress != nil {
+			progress(done)
+		}
+	}
+
+	return errs
+}
+
+// bulkError summarises the failures from a bulk operation, or returns nil
+// if every item succeeded. The failures are joined with errors.Join and
+// wrapped, so callers can test for individual ones with errors.Is and
+// errors.As.
+func bulkError(operation string, errs []error) error {
+	var failures []error
+	for _, err := range errs {
+		if err != nil {
+			failures = append(failures, err)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s failed for %d of %d todos: %w", operation, len(failures), len(errs), errors.Join(failures...))
+}
+
+// CreateTodos creates a todo for each input, several at a time, and returns
+// them in input order with nil for any that failed. For long imports,
+// progress is called with the number of finished creates and the total as
+// each one finishes; it is always called from the calling goroutine.
+// Progress is also logged every few seconds.
+func (c *Client) CreateTodos(ctx context.Context, inputs []TodoInput, progress func(done, total int)) ([]*Todo, error) {
+	todos := make([]*Todo, len(inputs))
+	total := len(inputs)
+
+	lastLog := time.Now()
+	errs := runBulkProgress(ctx, total, func(ctx context.Context, i int) error {
+		todo, err := c.CreateTodo(ctx, inputs[i])
+		todos[i] = todo
+		return err
+	}, func(done int) {
+		if progress != nil {
+			progress(done, total)
+		}
+		if done == total || time.Since(lastLog) >= bulkProgressLogInterval {
+			tflog.Info(ctx, "Bulk create progress", map[string]any{"done": done, "total": total})
+			lastLog = time.Now()
+		}
+	})
+
+	return todos, bulkError("create", errs)
+}
+
+// DeleteTodosOptions makes a DeleteTodos run resumable
+type DeleteTodosOptions struct {
+	// StartAfter skips the ids up to and including this one, resuming a
+	// run from the cursor its Checkpoint last received. Empty starts at
+	// the beginning.
+	StartAfter string
+
+	// Checkpoint, if set, is called with the id up to which every todo has
+	// been deleted, each time that point advances. It is always called
+	// from the calling goroutine. Store the id and pass it as StartAfter to
+	// skip those deletes when running again after an interruption.
+	Checkpoint func(id string)
+}
+
+// DeleteTodos deletes the todos with the given ids, several at a time, and
+// reports how many were deleted. Todos that are already gone count as
+// deleted. The checkpoint only advances past an id once it and every id
+// before it are deleted, so a re-run from the last checkpoint only repeats
+// deletes that finished after the first failure or cancellation, which is
+// harmless since deletes are idempotent.
+func (c *Client) DeleteTodos(ctx context.Context, ids []string, opts DeleteTodosOptions) (int, error) {
+	if opts.StartAfter != "" {
+		start := -1
+		for i, id := range ids {
+			if id == opts.StartAfter {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return 0, fmt.Errorf("delete todos: start after id %s is not in the list", opts.StartAfter)
+		}
+		ids = ids[start+1:]
+	}
+
+	var mu sync.Mutex
+	deleted := make([]bool, len(ids))
+	next := 0
+
+	errs := runBulkProgress(ctx, len(ids), func(ctx context.Context, i int) error {
+		if err := c.DeleteTodo(ctx, ids[i]); err != nil {
+			return err
+		}
+		mu.Lock()
+		deleted[i] = true
+		mu.Unlock()
+		return nil
+	}, func(int) {
+		mu.Lock()
+		before := next
+		for next < len(ids) && deleted[next] {
+			next++
+		}
+		mu.Unlock()
+
+		if next > before && opts.Checkpoint != nil {
+			opts.Checkpoint(ids[next-1])
+		}
+	})
+
+	count := 0
+	for _, err := range errs {
+		if err == nil {
+			count++
+		}
+	}
+
+	return count, bulkError("delete", errs)
+}
+
+// CompleteAllMatching marks every todo returned by the list endpoint for
+// filter as completed and reports how many were changed. Todos that are
+// already completed are left alone and not counted.
+func (c *Client) CompleteAllMatching(ctx context.Context, filter ListOptions) (int, error) {
+	var pending []string
+	err := c.ListTodosFunc(ctx, filter, func(todo Todo) error {
+		if !todo.Completed {
+			pending = append(pending, todo.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	completed := true
+	errs := runBulk(ctx, len(pending), func(ctx context.Context, i int) error {
+		_, err := c.UpdateTodo(ctx, pending[i], TodoInput{Completed: &completed})
+		return err
+	})
+
+	affected := 0
+	for _, err := range errs {
+		if err == nil {
+			affected++
+		}
+	}
+
+	return affected, bulkError("complete", errs)
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// BulkPlan is the set of changes that would make the API's todos match a
+// desired list, as computed by PlanBulk. Nothing is sent to the API.
+type BulkPlan struct {
+	// Create holds the desired todos that have no existing counterpart
+	Create []Todo
+
+	// Update holds the existing todos that differ from their desired state
+	Update []BulkUpdate
+
+	// Delete holds the existing todos that are not in the desired list
+	Delete []Todo
+}
+
+// BulkUpdate is one change in a BulkPlan to an existing todo
+type BulkUpdate struct {
+	Current Todo
+	Desired Todo
+
+	// Fields are the JSON names of the fields that would change
+	Fields []string
+}
+
+// Empty reports whether applying the plan would change nothing
+func (p BulkPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// Summary describes the plan's counts in the style of terraform plan
+func (p BulkPlan) Summary() string {
+	return fmt.Sprintf("%d to create, %d to update, %d to delete", len(p.Create), len(p.Update), len(p.Delete))
+}
+
+// PlanBulk lists the current todos and works out what would have to be
+// created, updated and deleted for them to match desired, without changing
+// anything. A desired todo with an ID is matched to the existing todo with
+// that ID, and it is an error if there is none, since IDs are assigned by
+// the server. One without an ID is matched to the first unmatched existing
+// todo with the same title and parent, and updated only if TodosEqual
+// finds a difference. Every existing todo left unmatched is planned for
+// deletion.
+func (c *Client) PlanBulk(ctx context.Context, desired []Todo) (BulkPlan, error) {
+	current, err := c.ListTodos(ctx, ListOptions{})
+	if err != nil {
+		return BulkPlan{}, fmt.Errorf("plan bulk: %w", err)
+	}
+
+	byID := make(map[string]int, len(current))
+	for i, todo := range current {
+		byID[todo.ID] = i
+	}
+	matched := make([]bool, len(current))
+
+	// Desired todos with IDs claim their counterparts first, so a todo
+	// without one cannot take an existing todo that is named explicitly
+	matches := make([]int, len(desired))
+	for i, todo := range desired {
+		matches[i] = -1
+		if todo.ID == "" {
+			continue
+		}
+		j, ok := byID[todo.ID]
+		if !ok {
+			return BulkPlan{}, fmt.Errorf("plan bulk: desired todo %s does not exist", todo.ID)
+		}
+		if matched[j] {
+			return BulkPlan{}, fmt.Errorf("plan bulk: todo %s is listed more than once", todo.ID)
+		}
+		matched[j] = true
+		matches[i] = j
+	}
+	for i, todo := range desired {
+		if todo.ID != "" {
+			continue
+		}
+		for j, existing := range current {
+			if !matched[j] && existing.Title == todo.Title && existing.ParentID == todo.ParentID {
+				matched[j] = true
+				matches[i] = j
+				break
+			}
+		}
+	}
+
+	var plan BulkPlan
+	for i, todo := range desired {
+		if matches[i] < 0 {
+			plan.Create = append(plan.Create, todo)
+			continue
+		}
+		existing := current[matches[i]]
+		if fields := changedTodoFields(existing, todo); len(fields) > 0 {
+			plan.Update = append(plan.Update, BulkUpdate{Current: existing, Desired: todo, Fields: fields})
+		}
+	}
+	for j, existing := range current {
+		if !matched[j] {
+			plan.Delete = append(plan.Delete, existing)
+		}
+	}
+
+	return plan, nil
+}
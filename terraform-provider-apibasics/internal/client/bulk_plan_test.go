@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// listServer answers GET /todos with todos and fails any other request
+func listServer(t *testing.T, todos []Todo) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/todos" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		writeJSON(t, w, http.StatusOK, todos)
+	})
+	return newTestClient(srv)
+}
+
+func TestPlanBulk(t *testing.T) {
+	c := listServer(t, []Todo{
+		{ID: testTodoID, Title: "Unchanged", Completed: true, CompletedAt: "2024-05-01T12:00:00Z"},
+		{ID: testTodoID2, Title: "Rename me"},
+		{ID: testTodoID3, Title: "Stale"},
+	})
+
+	plan, err := c.PlanBulk(context.Background(), []Todo{
+		{Title: "Unchanged", Completed: true, CompletedAt: "2024-05-01T14:00:00+02:00"},
+		{ID: testTodoID2, Title: "Renamed", Description: "by id"},
+		{Title: "Brand new"},
+	})
+	if err != nil {
+		t.Fatalf("PlanBulk() error = %v", err)
+	}
+
+	if len(plan.Create) != 1 || plan.Create[0].Title != "Brand new" {
+		t.Errorf("Create = %+v, want only %q", plan.Create, "Brand new")
+	}
+	if len(plan.Update) != 1 || plan.Update[0].Current.ID != testTodoID2 {
+		t.Fatalf("Update = %+v, want only %s", plan.Update, testTodoID2)
+	}
+	if want := []string{"title", "description"}; !reflect.DeepEqual(plan.Update[0].Fields, want) {
+		t.Errorf("Update fields = %v, want %v", plan.Update[0].Fields, want)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].ID != testTodoID3 {
+		t.Errorf("Delete = %+v, want only %s", plan.Delete, testTodoID3)
+	}
+	if got, want := plan.Summary(), "1 to create, 1 to update, 1 to delete"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	if plan.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+}
+
+func TestPlanBulkExplicitIDClaimsBeforeTitle(t *testing.T) {
+	c := listServer(t, []Todo{
+		{ID: testTodoID, Title: "Same"},
+		{ID: testTodoID2, Title: "Same"},
+	})
+
+	// Matching by title must not take testTodoID, which is named later
+	plan, err := c.PlanBulk(context.Background(), []Todo{
+		{Title: "Same"},
+		{ID: testTodoID, Title: "Same"},
+	})
+	if err != nil {
+		t.Fatalf("PlanBulk() error = %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan = %+v, want empty", plan)
+	}
+}
+
+func TestPlanBulkErrors(t *testing.T) {
+	c := listServer(t, []Todo{{ID: testTodoID, Title: "Exists"}})
+
+	tests := []struct {
+		name    string
+		desired []Todo
+		want    string
+	}{
+		{name: "unknown id", desired: []Todo{{ID: testTodoID2, Title: "Missing"}}, want: "does not exist"},
+		{name: "repeated id", desired: []Todo{{ID: testTodoID, Title: "a"}, {ID: testTodoID, Title: "b"}}, want: "more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.PlanBulk(context.Background(), tt.desired)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("PlanBulk() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCreateTodosReportsMonotonicProgress(t *testing.T) {
+	var created atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		// Vary the latency so creates finish out of order
+		time.Sleep(time.Duration(created.Add(1)%3) * time.Millisecond)
+		writeJSON(t, w, http.StatusCreated, Todo{ID: fmt.Sprintf("00000000-0000-4000-8000-%012s", body["title"]), Title: body["title"]})
+	})
+	c := newTestClient(srv)
+
+	inputs := make([]TodoInput, 10)
+	for i := range inputs {
+		title := fmt.Sprint(i)
+		inputs[i] = TodoInput{Title: &title}
+	}
+
+	// Not synchronised: the race detector flags calls from several goroutines
+	var progress []int
+	todos, err := c.CreateTodos(context.Background(), inputs, func(done, total int) {
+		if total != len(inputs) {
+			t.Errorf("total = %d, want %d", total, len(inputs))
+		}
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatalf("CreateTodos() error = %v", err)
+	}
+
+	for i, done := range progress {
+		if done != i+1 {
+			t.Fatalf("progress = %v, want 1 through %d in order", progress, len(inputs))
+		}
+	}
+	if len(progress) != len(inputs) {
+		t.Errorf("progress called %d times, want %d", len(progress), len(inputs))
+	}
+	// Results keep the input order whatever order the creates finished in
+	for i, todo := range todos {
+		if todo == nil || todo.Title != fmt.Sprint(i) {
+			t.Errorf("todos[%d] = %+v, want title %d", i, todo, i)
+		}
+	}
+}
+
+// deleteServer deletes todos from a set, answering 404 for ones already
+// gone, and fails deletes of the ids in failing
+type deleteServer struct {
+	mu       sync.Mutex
+	existing map[string]bool
+	failing  map[string]bool
+	calls    int
+}
+
+func (s *deleteServer) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.calls++
+
+		id := strings.TrimPrefix(r.URL.Path, "/todos/")
+		switch {
+		case s.failing[id]:
+			w.WriteHeader(http.StatusBadRequest)
+		case !s.existing[id]:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			delete(s.existing, id)
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+}
+
+func TestDeleteTodosResumesFromCheckpoint(t *testing.T) {
+	todos := numberedTodos(10)
+	ids := make([]string, len(todos))
+	s := &deleteServer{existing: map[string]bool{}, failing: map[string]bool{}}
+	for i, todo := range todos {
+		ids[i] = todo.ID
+		s.existing[todo.ID] = true
+	}
+	c := newTestClient(newTestServer(t, s.handler()))
+
+	// The first run is interrupted by a failure part way through
+	s.failing[ids[5]] = true
+	var checkpoint string
+	count, err := c.DeleteTodos(context.Background(), ids, DeleteTodosOptions{
+		Checkpoint: func(id string) { checkpoint = id },
+	})
+	if err == nil || count != 9 {
+		t.Fatalf("DeleteTodos() = %d, %v, want 9 and an error", count, err)
+	}
+	// Later todos were deleted, but the checkpoint cannot pass the failure
+	if checkpoint != ids[4] {
+		t.Fatalf("checkpoint = %s, want %s, the last of the deleted prefix", checkpoint, ids[4])
+	}
+
+	s.mu.Lock()
+	s.failing = nil
+	s.calls = 0
+	s.mu.Unlock()
+	count, err = c.DeleteTodos(context.Background(), ids, DeleteTodosOptions{
+		StartAfter: checkpoint,
+		Checkpoint: func(id string) { checkpoint = id },
+	})
+	if err != nil {
+		t.Fatalf("resumed DeleteTodos() error = %v", err)
+	}
+	if count != 5 || s.calls != 5 {
+		t.Errorf("resumed run deleted %d with %d requests, want 5 of each", count, s.calls)
+	}
+	if checkpoint != ids[9] {
+		t.Errorf("checkpoint = %s, want the last id %s", checkpoint, ids[9])
+	}
+	if len(s.existing) != 0 {
+		t.Errorf("%d todos left, want none", len(s.existing))
+	}
+}
+
+func TestDeleteTodosRejectsUnknownStartAfter(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := newTestClient(srv).DeleteTodos(context.Background(), []string{testTodoID}, DeleteTodosOptions{StartAfter: testTodoID2})
+	if err == nil {
+		t.Errorf("DeleteTodos() error = nil, want an error for a cursor not in the list")
+	}
+}
+
+func TestCompleteAllMatchingJoinsFailures(t *testing.T) {
+	todos := numberedTodos(4)
+	missing, locked := todos[1].ID, todos[3].ID
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/todos/") {
+		case "/todos":
+			writeJSON(t, w, http.StatusOK, todoPage{Todos: todos})
+		case missing:
+			w.WriteHeader(http.StatusNotFound)
+		case locked:
+			writeJSON(t, w, http.StatusBadRequest, map[string]string{"message": "todo is locked"})
+		default:
+			writeJSON(t, w, http.StatusOK, Todo{ID: strings.TrimPrefix(r.URL.Path, "/todos/"), Completed: true})
+		}
+	})
+
+	count, err := newTestClient(srv).CompleteAllMatching(context.Background(), ListOptions{})
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound for the missing todo", err)
+	}
+
+	// Each failure can be inspected on its own
+	joined, ok := errors.Unwrap(err).(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("error = %v, want it to wrap the joined failures", err)
+	}
+	var statuses []int
+	for _, failure := range joined.Unwrap() {
+		var apiErr *APIError
+		if errors.As(failure, &apiErr) {
+			statuses = append(statuses, apiErr.StatusCode)
+		}
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusNotFound || statuses[1] != http.StatusBadRequest {
+		t.Errorf("failure statuses = %v, want 404 and 400 in list order", statuses)
+	}
+}
+
+func TestBulkError(t *testing.T) {
+	if err := bulkError("delete", []error{nil, nil}); err != nil {
+		t.Errorf("bulkError() without failures = %v, want nil", err)
+	}
+
+	err := bulkError("delete", []error{nil, ErrNotFound, context.Canceled})
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, context.Canceled) {
+		t.Errorf("bulkError() = %v, want it to wrap every failure", err)
+	}
+	if !strings.HasPrefix(err.Error(), "delete failed for 2 of 3 todos: ") {
+		t.Errorf("bulkError() = %q, want the failure count", err)
+	}
+}
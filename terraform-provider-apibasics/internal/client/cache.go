@@ -0,0 +1,114 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultReadCacheTTL is how long a cached todo is served without asking
+// the API again
+const DefaultReadCacheTTL = 5 * time.Second
+
+// readCache holds recently fetched todos so that repeated reads of the same
+// todo within one Terraform run are served locally
+type readCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]readCacheEntry
+}
+
+// readCacheEntry is a cached todo and the ETag it was served with
+type readCacheEntry struct {
+	todo    Todo
+	etag    string
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		entries: map[string]readCacheEntry{},
+	}
+}
+
+// get returns the cached todo for id, whether it is still fresh, and the
+// ETag to revalidate it with once it is stale
+func (rc *readCache) get(id string) (todo *Todo, fresh bool, etag string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[id]
+	if !ok {
+		return nil, false, ""
+	}
+	if time.Now().Before(entry.expires) {
+		return entry.todo.clone(), true, entry.etag
+	}
+	if entry.etag == "" {
+		// Nothing to revalidate with, so the entry is useless
+		delete(rc.entries, id)
+		return nil, false, ""
+	}
+	return entry.todo.clone(), false, entry.etag
+}
+
+// put stores todo, replacing any previous entry for its id
+func (rc *readCache) put(todo *Todo, etag string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries[todo.ID] = readCacheEntry{
+		todo:    *todo.clone(),
+		etag:    etag,
+		expires: time.Now().Add(rc.ttl),
+	}
+}
+
+// touch extends the lifetime of an entry the API confirmed is unchanged
+func (rc *readCache) touch(id string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if entry, ok := rc.entries[id]; ok {
+		entry.expires = time.Now().Add(rc.ttl)
+		rc.entries[id] = entry
+	}
+}
+
+// invalidate drops the entry for id after a write
+func (rc *readCache) invalidate(id string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, id)
+}
+
+// clone returns a copy of t that shares no maps or slices with it
+func (t *Todo) clone() *Todo {
+	cp := *t
+	if t.Metadata != nil {
+		cp.Metadata = make(map[string]string, len(t.Metadata))
+		for k, v := range t.Metadata {
+			cp.Metadata[k] = v
+		}
+	}
+	cp.Warnings = append([]string(nil), t.Warnings...)
+	if t.fields != nil {
+		cp.fields = make(map[string]struct{}, len(t.fields))
+		for name := range t.fields {
+			cp.fields[name] = struct{}{}
+		}
+	}
+	return &cp
+}
+
+// EnableReadCache makes GetTodo serve repeated reads of a todo from memory
+// for ttl. Once an entry is stale it is revalidated with If-None-Match when
+// the API supplied an ETag. Updates and deletes invalidate the entry.
+func (c *Client) EnableReadCache(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultReadCacheTTL
+	}
+	c.readCache = newReadCache(ttl)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cachingServer serves a todo, counting GETs, and accepts updates to it
+func cachingServer(t *testing.T, gets *atomic.Int32) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			n := gets.Add(1)
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Read", Metadata: map[string]string{"read": strconv.Itoa(int(n))}})
+		case http.MethodPut:
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Written"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	c := newTestClient(srv)
+	c.EnableReadCache(time.Minute)
+	return c
+}
+
+func TestReadCacheServesRepeatedReads(t *testing.T) {
+	var gets atomic.Int32
+	c := cachingServer(t, &gets)
+
+	first, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	// Callers get copies, so changing one must not alter the cached todo
+	first.Metadata["read"] = "changed"
+
+	second, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if gets.Load() != 1 {
+		t.Errorf("server saw %d reads, want 1", gets.Load())
+	}
+	if second.Metadata["read"] != "1" {
+		t.Errorf("cached metadata = %v, want it unchanged by the caller", second.Metadata)
+	}
+}
+
+func TestReadCacheInvalidatedByUpdate(t *testing.T) {
+	var gets atomic.Int32
+	c := cachingServer(t, &gets)
+
+	if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	title := "Written"
+	if _, err := c.UpdateTodo(context.Background(), testTodoID, TodoInput{Title: &title}); err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("server saw %d reads, want 2 after the update invalidated the cache", gets.Load())
+	}
+}
@@ -2,23 +2,190 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
 )
 
 // Client manages communication with the API Basics API
 type Client struct {
-	BaseURL      string
-	Email        string
-	Password     string
-	AccessToken  string
+	BaseURL     string
+	Email       string
+	Password    string
+	AccessToken string
+	HTTPClient  *http.Client
+
+	// RefreshToken is the refresh token from the last login. Grants such as
+	// client_credentials don't issue one, so it may be empty; a 401 then
+	// re-runs the login with the configured credentials.
 	RefreshToken string
-	HTTPClient   *http.Client
+
+	// Credentials, when set, supplies the access token for each request
+	// instead of the email and password login, see CredentialProvider.
+	// AccessToken, RefreshToken and TokenType are then unused.
+	Credentials CredentialProvider
+
+	// TokenType is the token_type from the last login, which names the
+	// scheme the access token is sent with. Empty means Bearer.
+	TokenType string
+
+	// AuthScheme, when set, overrides TokenType as the Authorization
+	// scheme, for servers that expect a non-standard one
+	AuthScheme string
+
+	// TokenPath is the path of the token endpoint used to authenticate
+	TokenPath string
+
+	// RefreshPath is the path of the endpoint Refresh exchanges the
+	// refresh token at
+	RefreshPath string
+
+	// MaxRetries is the number of times an idempotent request is retried
+	// after a transient server error
+	MaxRetries int
+
+	// SlowRequestThreshold logs a warning for any request that takes longer
+	// than this duration. Zero disables the check.
+	SlowRequestThreshold time.Duration
+
+	// PerRequestTimeout bounds a single attempt, including reading its
+	// response body. Retries each get a fresh deadline; the caller's context
+	// still bounds the operation as a whole. Zero disables it.
+	PerRequestTimeout time.Duration
+
+	// ConfirmNotFound makes GetTodo re-check a 404 once after a short
+	// delay before reporting the todo as not found
+	ConfirmNotFound bool
+
+	// RequestPriority is sent as the Priority header on every request so
+	// the gateway can deprioritise automated traffic. Empty sends no header.
+	RequestPriority string
+
+	// CompletedEncoding selects how the completed flag is sent to the API
+	CompletedEncoding CompletedEncoding
+
+	// FollowAsync makes creates and updates that return 202 Accepted with a
+	// Location header poll that status URL until the operation completes,
+	// then return the resulting todo
+	FollowAsync bool
+
+	// UserAgent is sent as the User-Agent header on every request when set
+	UserAgent string
+
+	// SendRequestIDs sends a random X-Request-ID with every request so it
+	// can be traced through the API's logs, see LastRequestID. NewClient
+	// enables it.
+	SendRequestIDs bool
+
+	// SensitiveFields are JSON keys whose values are redacted from logged
+	// request and response bodies, in addition to DefaultSensitiveFields
+	SensitiveFields []string
+
+	// SigningSecret, when set, signs every request with an HMAC-SHA256
+	// X-Signature header in addition to the bearer token
+	SigningSecret string
+
+	// APIKey, when set, is sent as the api_key query parameter on every
+	// request, for deployments that authenticate that way. The bearer token
+	// is still sent when there is one.
+	APIKey string
+
+	// PreferMinimal sends Prefer: return=minimal on creates and updates so
+	// the server may answer without a body. The todo is then read back
+	// with a follow-up GET.
+	PreferMinimal bool
+
+	// LenientDecode accepts "completed" flags sent as strings or 0/1 numbers
+	// rather than JSON booleans, for backends that encode them loosely
+	LenientDecode bool
+
+	// Middleware wraps every authenticated request, running after the
+	// built-in auth, priority and user agent middleware
+	Middleware []Middleware
+
+	// RetryBaseDelay and RetryMaxDelay bound the exponential backoff
+	// between retries. Zero uses DefaultRetryBaseDelay and
+	// DefaultRetryMaxDelay.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// RetryBudget caps the total time a request may spend across all of its
+	// attempts, including waits between them. No retry is started that
+	// would exceed it. Zero means only MaxRetries applies.
+	RetryBudget time.Duration
+
+	// ShouldRetry decides whether a failed attempt is retried. It is given
+	// the request, the response or transport error, and the zero-based
+	// attempt number. It is consulted for idempotent requests, and for 429
+	// Too Many Requests responses whatever the method, since a rate-limited
+	// request was not processed. Temporary DNS errors are retried without
+	// asking it. MaxRetries still caps the number of retries, so it can veto
+	// or allow retries but never exceed the budget. Nil uses
+	// DefaultShouldRetry.
+	ShouldRetry func(req *http.Request, resp *http.Response, err error, attempt int) bool
+
+	// RetryableErrorMessages enables detection of error envelopes (a JSON
+	// "error" field) in 2xx responses. Any such response becomes an
+	// APIError; idempotent requests are retried first when the message
+	// contains one of these substrings, compared case-insensitively.
+	RetryableErrorMessages []string
+
+	// readCache serves repeated GetTodo calls locally when enabled with
+	// EnableReadCache. Nil disables caching.
+	readCache *readCache
+
+	// updateBatcher coalesces concurrent UpdateTodo calls when enabled
+	// with EnableUpdateBatching. Nil sends each update on its own.
+	updateBatcher *updateBatcher
+
+	// requestSlots holds a token for each request in flight when limited
+	// with LimitConcurrentRequests. Nil means no limit.
+	requestSlots chan struct{}
+
+	// requestIDMu guards lastRequestID, the id LastRequestID reports
+	requestIDMu   sync.Mutex
+	lastRequestID string
+
+	// reauthGroup coalesces the token renewals, by refresh or by login, of
+	// requests rejected at the same time and of proactive renewals, see
+	// reauthenticate
+	reauthGroup singleflight.Group
+
+	// tokenExpiry is when the access token from the last login expires,
+	// or zero if the token endpoint did not say
+	tokenExpiry time.Time
+
+	// tokenMu guards AccessToken, RefreshToken, TokenType and tokenExpiry
+	tokenMu sync.Mutex
 }
 
+// DefaultTokenPath is the token endpoint path used when none is configured
+const DefaultTokenPath = "/token"
+
+// DefaultRefreshPath is the token refresh endpoint path used when none is
+// configured
+const DefaultRefreshPath = "/refresh"
+
+// Default connection setup timeouts, matching Go's default transport
+const (
+	DefaultDialTimeout         = 30 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// maxRedirects matches the redirect limit of Go's default HTTP client
+const maxRedirects = 10
+
 // NewClient creates a new API client
 func NewClient(baseURL, email, password string) *Client {
 	return &Client{
@@ -26,11 +193,125 @@ func NewClient(baseURL, email, password string) *Client {
 		Email:    email,
 		Password: password,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:       30 * time.Second,
+			Transport:     newTransport(DefaultDialTimeout, DefaultTLSHandshakeTimeout),
+			CheckRedirect: checkRedirect,
 		},
+		TokenPath:         DefaultTokenPath,
+		RefreshPath:       DefaultRefreshPath,
+		MaxRetries:        DefaultMaxRetries,
+		CompletedEncoding: CompletedEncodingBool,
+		SendRequestIDs:    true,
 	}
 }
 
+// WithBaseURL returns a new client with the same credentials and settings
+// as c but sending requests to baseURL. Tokens are not shared, so the new
+// client must Authenticate before use. Slice settings are copied, so
+// changing them on one client does not affect the other.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	clone := NewClient(baseURL, c.Email, c.Password)
+	clone.HTTPClient = c.HTTPClient
+	clone.TokenPath = c.TokenPath
+	clone.RefreshPath = c.RefreshPath
+	clone.MaxRetries = c.MaxRetries
+	clone.SlowRequestThreshold = c.SlowRequestThreshold
+	clone.PerRequestTimeout = c.PerRequestTimeout
+	clone.ConfirmNotFound = c.ConfirmNotFound
+	clone.RequestPriority = c.RequestPriority
+	clone.CompletedEncoding = c.CompletedEncoding
+	clone.RetryableErrorMessages = append(c.RetryableErrorMessages[:0:0], c.RetryableErrorMessages...)
+	clone.UserAgent = c.UserAgent
+	clone.SendRequestIDs = c.SendRequestIDs
+	clone.FollowAsync = c.FollowAsync
+	clone.ShouldRetry = c.ShouldRetry
+	clone.RetryBaseDelay = c.RetryBaseDelay
+	clone.RetryMaxDelay = c.RetryMaxDelay
+	clone.RetryBudget = c.RetryBudget
+	clone.Middleware = append(c.Middleware[:0:0], c.Middleware...)
+	clone.SensitiveFields = append(c.SensitiveFields[:0:0], c.SensitiveFields...)
+	clone.SigningSecret = c.SigningSecret
+	clone.LenientDecode = c.LenientDecode
+	clone.PreferMinimal = c.PreferMinimal
+	clone.APIKey = c.APIKey
+	clone.AuthScheme = c.AuthScheme
+	// A credential provider manages its own tokens, so it is shared
+	clone.Credentials = c.Credentials
+	if c.readCache != nil {
+		// Todos on another endpoint are unrelated, so use a separate cache
+		clone.EnableReadCache(c.readCache.ttl)
+	}
+	if c.updateBatcher != nil {
+		clone.EnableUpdateBatching(c.updateBatcher.window)
+	}
+	// The limit protects the local machine, so it spans every endpoint
+	clone.requestSlots = c.requestSlots
+	return clone
+}
+
+// LimitConcurrentRequests caps the number of requests in flight at once at
+// n, counting each from when it is sent until its response body is closed.
+// Further requests wait for a free slot or for their context to end. Zero
+// or less removes the limit. Call it before the client is used.
+func (c *Client) LimitConcurrentRequests(n int) {
+	if n <= 0 {
+		c.requestSlots = nil
+		return
+	}
+	c.requestSlots = make(chan struct{}, n)
+}
+
+// SetTransportTimeouts replaces the HTTP transport with one that uses the
+// given connection and TLS handshake timeouts. These bound only connection
+// setup, unlike HTTPClient.Timeout which covers the whole request including
+// reading the body. A zero value keeps the default for that timeout.
+func (c *Client) SetTransportTimeouts(dialTimeout, tlsHandshakeTimeout time.Duration) {
+	if dialTimeout == 0 {
+		dialTimeout = DefaultDialTimeout
+	}
+	if tlsHandshakeTimeout == 0 {
+		tlsHandshakeTimeout = DefaultTLSHandshakeTimeout
+	}
+
+	c.HTTPClient.Transport = newTransport(dialTimeout, tlsHandshakeTimeout)
+}
+
+// newTransport builds an HTTP transport based on Go's default transport
+// with the given connection timeouts
+func newTransport(dialTimeout, tlsHandshakeTimeout time.Duration) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = tlsHandshakeTimeout
+	return transport
+}
+
+// checkRedirect keeps the Authorization header on same-host redirects (such
+// as http to https), which Go's default policy would otherwise strip, and
+// refuses cross-host redirects with an explanatory error instead of letting
+// them fail later as a confusing 401.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	original := via[0]
+	if req.URL.Hostname() != original.URL.Hostname() {
+		return fmt.Errorf(
+			"endpoint redirected from %s to a different host (%s); the Authorization header is not forwarded across hosts, so set the provider endpoint to the redirect target",
+			original.URL.Host, req.URL.Host,
+		)
+	}
+
+	if auth := original.Header.Get("Authorization"); auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	return nil
+}
+
 // TokenResponse represents the OAuth token response
 type TokenResponse struct {
 	TokenType    string `json:"token_type"`
@@ -39,89 +320,456 @@ type TokenResponse struct {
 	ExpiresIn    int    `json:"expires_in"`
 }
 
-// Authenticate logs in and retrieves access tokens
-func (c *Client) Authenticate() error {
-	loginData := map[string]string{
+// Authenticate logs in and retrieves access tokens. Temporary DNS failures
+// are retried with backoff, up to MaxRetries times, until ctx is done.
+func (c *Client) Authenticate(ctx context.Context) error {
+	tokenResp, err := c.requestToken(ctx)
+	for attempt := 0; err != nil && isTemporaryDNSError(err) && attempt < c.MaxRetries; attempt++ {
+		if sleepErr := sleepContext(ctx, c.backoffDelay(attempt)); sleepErr != nil {
+			return fmt.Errorf("auth request failed: %w", sleepErr)
+		}
+		tokenResp, err = c.requestToken(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.storeTokens(tokenResp)
+	return nil
+}
+
+// ErrNoRefreshToken is returned by Refresh when the last login did not
+// issue a refresh token
+var ErrNoRefreshToken = errors.New("no refresh token")
+
+// Refresh exchanges the refresh token for a new access token, without
+// sending the email and password. The refresh token is kept unless the
+// server rotates it.
+func (c *Client) Refresh(ctx context.Context) error {
+	c.tokenMu.Lock()
+	refreshToken := c.RefreshToken
+	c.tokenMu.Unlock()
+	if refreshToken == "" {
+		return ErrNoRefreshToken
+	}
+
+	tokenResp, err := c.postToken(ctx, "token refresh", c.RefreshPath, map[string]string{"refresh_token": refreshToken})
+	if err != nil {
+		return err
+	}
+
+	if tokenResp.RefreshToken == "" {
+		tokenResp.RefreshToken = refreshToken
+	}
+	c.storeTokens(tokenResp)
+	return nil
+}
+
+// storeTokens makes the tokens from a token response current
+func (c *Client) storeTokens(tokenResp *TokenResponse) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	c.AccessToken = tokenResp.AccessToken
+	c.RefreshToken = tokenResp.RefreshToken
+	c.TokenType = tokenResp.TokenType
+	c.tokenExpiry = time.Time{}
+	if tokenResp.ExpiresIn > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+}
+
+// requestToken exchanges the email and password for tokens without storing them
+func (c *Client) requestToken(ctx context.Context) (*TokenResponse, error) {
+	return c.postToken(ctx, "authentication", c.TokenPath, map[string]string{
 		"email":    c.Email,
 		"password": c.Password,
-	}
+	})
+}
 
-	body, err := json.Marshal(loginData)
+// postToken sends payload to the token endpoint at path and returns the
+// tokens it issues, without storing them. operation names the exchange in
+// API errors.
+func (c *Client) postToken(ctx context.Context, operation, path string, payload map[string]string) (*TokenResponse, error) {
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal login data: %w", err)
+		return nil, fmt.Errorf("failed to marshal login data: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/token", bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+path, bytes.NewBuffer(body))
 	if err != nil {
-		return fmt.Errorf("failed to create auth request: %w", err)
+		return nil, fmt.Errorf("failed to create auth request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("auth request failed: %w", err)
+		return nil, fmt.Errorf("auth request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(operation, resp)
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read auth response: %w", err)
+		return nil, fmt.Errorf("failed to read auth response: %w", err)
 	}
 
 	var tokenResp TokenResponse
 	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
-		return fmt.Errorf("failed to parse auth response: %w", err)
+		return nil, fmt.Errorf("failed to parse auth response: %w", err)
 	}
 
-	c.AccessToken = tokenResp.AccessToken
-	c.RefreshToken = tokenResp.RefreshToken
+	// The refresh token is optional, but without an access token every
+	// request would be rejected
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("auth response did not include an access token")
+	}
 
-	return nil
+	return &tokenResp, nil
 }
 
-// DoRequest makes an authenticated HTTP request
-func (c *Client) DoRequest(method, path string, body interface{}) (*http.Response, error) {
-	var reqBody io.Reader
+// ForceReauthenticate discards the current tokens and logs in again. Use it
+// after rotating credentials or when the server has revoked the token.
+func (c *Client) ForceReauthenticate(ctx context.Context) error {
+	c.tokenMu.Lock()
+	c.AccessToken = ""
+	c.RefreshToken = ""
+	c.TokenType = ""
+	c.tokenMu.Unlock()
+
+	return c.Authenticate(ctx)
+}
+
+// reauthenticate gets a new access token after a request sent with
+// rejectedToken got a 401, using the refresh token if there is one and
+// logging in again if not or if the refresh fails. When many requests are
+// rejected at once, as when a token expires, they all wait for a single
+// renewal and share its result. A request whose token has already been
+// replaced by such a renewal just retries with the new one.
+//
+// The shared renewal is not cancelled with the ctx of the request that
+// started it, since the others are waiting on it too, but each caller stops
+// waiting once its own ctx is done.
+func (c *Client) reauthenticate(ctx context.Context, rejectedToken string) error {
+	renewCtx := context.WithoutCancel(ctx)
+	results := c.reauthGroup.DoChan("authenticate", func() (any, error) {
+		if token := c.accessToken(); token != "" && token != rejectedToken {
+			return nil, nil
+		}
+
+		err := c.Refresh(renewCtx)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, ErrNoRefreshToken) {
+			tflog.Debug(renewCtx, "Token refresh failed, logging in again", map[string]any{"error": err.Error()})
+		}
+		return nil, c.Authenticate(renewCtx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-results:
+		return result.Err
+	}
+}
+
+// renewExpiringToken logs in again ahead of time when the access token is
+// about to expire, saving the request a 401 and a retry. A failed login is
+// only logged: the request goes ahead with the old token and the 401
+// handling tries again.
+func (c *Client) renewExpiringToken(ctx context.Context) {
+	if c.Credentials != nil {
+		return
+	}
+
+	c.tokenMu.Lock()
+	token, refreshToken, expiry := c.AccessToken, c.RefreshToken, c.tokenExpiry
+	c.tokenMu.Unlock()
+	if token == "" || expiry.IsZero() || time.Until(expiry) > tokenExpiryMargin {
+		return
+	}
+	if refreshToken == "" && c.Email == "" {
+		return
+	}
+
+	if err := c.reauthenticate(ctx, token); err != nil {
+		tflog.Warn(ctx, "Could not renew expiring access token", map[string]any{"error": err.Error()})
+	}
+}
+
+// accessToken returns the current access token
+func (c *Client) accessToken() string {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	return c.AccessToken
+}
+
+// authorization returns the Authorization header value for token, using
+// AuthScheme if set, else the scheme named by TokenType
+func (c *Client) authorization(token string) string {
+	scheme := c.AuthScheme
+	if scheme == "" {
+		c.tokenMu.Lock()
+		scheme = authScheme(c.TokenType)
+		c.tokenMu.Unlock()
+	}
+	return scheme + " " + token
+}
+
+// knownAuthSchemes maps lowercased token types to their conventional
+// spelling in the Authorization header
+var knownAuthSchemes = map[string]string{
+	"bearer": "Bearer",
+	"dpop":   "DPoP",
+	"mac":    "MAC",
+	"basic":  "Basic",
+}
+
+// authScheme returns the Authorization scheme for a token_type. Token types
+// are case-insensitive, but some servers only accept the conventional
+// spelling, e.g. "Bearer" for "bearer". An empty type means Bearer.
+func authScheme(tokenType string) string {
+	if tokenType == "" {
+		return "Bearer"
+	}
+	if scheme, ok := knownAuthSchemes[strings.ToLower(tokenType)]; ok {
+		return scheme
+	}
+	return strings.ToUpper(tokenType[:1]) + tokenType[1:]
+}
+
+// DoRequest makes an authenticated HTTP request. Whether a failed request
+// may be retried is inferred from its HTTP method; use DoRequestWithOptions
+// to override that.
+func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.DoRequestWithOptions(ctx, method, path, body, RequestOptions{})
+}
+
+// DoRequestWithOptions makes an authenticated HTTP request, retrying
+// transient failures as decided by ShouldRetry when the request is
+// idempotent or was rate limited
+func (c *Client) DoRequestWithOptions(ctx context.Context, method, path string, body interface{}, opts RequestOptions) (*http.Response, error) {
+	return c.doRequest(ctx, method, path, body, opts, false)
+}
+
+// doRequest implements DoRequestWithOptions. reauthenticated is set once the
+// request has already logged in again after a 401, so a token the server
+// keeps rejecting is reported instead of looping.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, opts RequestOptions, reauthenticated bool) (*http.Response, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	retryable := opts.Idempotency.allowsRetry(method)
+
+	shouldRetry := c.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = DefaultShouldRetry
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		c.renewExpiringToken(ctx)
+
+		var sentToken string
+		req, resp, err := c.send(withSentTokenSlot(ctx, &sentToken), method, path, jsonBody, opts)
+		if req == nil {
+			return nil, err
+		}
+
+		// A credential provider that caches tokens is told the token was
+		// rejected and the request retried once; other providers' 401s are
+		// returned as is
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.Credentials != nil && !reauthenticated {
+			if invalidator, ok := c.Credentials.(TokenInvalidator); ok {
+				resp.Body.Close()
+				invalidator.InvalidateToken(sentToken)
+				return c.doRequest(ctx, method, path, body, opts, true)
+			}
+		}
+
+		// Handle 401 - try to re-authenticate. A 403 means the token is
+		// valid but not allowed, so it is returned to the caller as is, as
+		// is a 401 when only an API key is configured, since there is
+		// nothing to log in with.
+		apiKeyOnly := c.APIKey != "" && c.Email == ""
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.Credentials == nil && !apiKeyOnly && !reauthenticated {
+			resp.Body.Close()
+			if err := c.reauthenticate(ctx, sentToken); err != nil {
+				return nil, fmt.Errorf("re-authentication failed: %w", err)
+			}
+			// Retry the request
+			return c.doRequest(ctx, method, path, body, opts, true)
+		}
+
+		// Some backends report failures in the body of a 2xx response
+		var envelopeErr *APIError
+		if err == nil && len(c.RetryableErrorMessages) > 0 && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			message, bodyBytes, readErr := readErrorEnvelope(resp)
+			if readErr != nil {
+				resp.Body.Close()
+				return nil, readErr
+			}
+			if message != "" {
+				envelopeErr = &APIError{
+					Operation:  opts.operation(method, path),
+					StatusCode: resp.StatusCode,
+					Body:       string(bodyBytes),
+					Message:    message,
+					RequestID:  responseRequestID(resp),
+				}
+			}
+		}
+
+		retry := false
+		switch {
+		case ctx.Err() != nil:
+			// The caller gave up, so there is no point retrying
+		case err != nil && isTemporaryDNSError(err):
+			retry = true
+		case err == nil && resp.StatusCode == http.StatusTooManyRequests:
+			// A rate-limited request was turned away before it was
+			// processed, so it is safe to send again whatever its method
+			retry = shouldRetry(req, resp, err, attempt)
+		case retryable && envelopeErr != nil:
+			retry = c.isRetryableErrorMessage(envelopeErr.Message)
+		case retryable:
+			retry = shouldRetry(req, resp, err, attempt)
+		}
+
+		if retry {
+			// A rate-limited request waits as long as the server asks, if it says
+			delay := c.backoffDelay(attempt)
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				delay = retryAfter(resp.Header, delay)
+			}
+			overBudget := c.RetryBudget > 0 && time.Since(start)+delay > c.RetryBudget
+			if attempt >= c.MaxRetries || overBudget {
+				if attempt > 0 || overBudget {
+					fields := map[string]any{
+						"method":   method,
+						"path":     path,
+						"attempts": attempt + 1,
+					}
+					if resp != nil {
+						fields["status"] = resp.StatusCode
+					}
+					tflog.Warn(ctx, "API request failed after retries", fields)
+				}
+				if envelopeErr != nil {
+					resp.Body.Close()
+					return nil, envelopeErr
+				}
+				return resp, err
+			}
+
+			// Headers are deliberately not logged so the Authorization token never appears
+			fields := map[string]any{
+				"method":   method,
+				"path":     path,
+				"attempt":  attempt + 1,
+				"delay_ms": delay.Milliseconds(),
+			}
+			if resp != nil {
+				resp.Body.Close()
+				fields["status"] = resp.StatusCode
+			} else {
+				fields["error"] = err.Error()
+			}
+			tflog.Debug(ctx, "Retrying API request", fields)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, fmt.Errorf("request cancelled while waiting to retry: %w", err)
+			}
+			continue
+		}
+
+		if envelopeErr != nil {
+			resp.Body.Close()
+			return nil, envelopeErr
+		}
+
+		return resp, err
 	}
+}
 
-	resp, err := c.HTTPClient.Do(req)
+// send performs a single attempt of an authenticated request, passing it
+// through the middleware chain
+func (c *Client) send(ctx context.Context, method, path string, jsonBody []byte, opts RequestOptions) (*http.Request, *http.Response, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if c.PerRequestTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.PerRequestTimeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		cancel()
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, values := range opts.Headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if jsonBody != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Handle 401 - try to re-authenticate
-	if resp.StatusCode == http.StatusUnauthorized {
-		resp.Body.Close()
-		if err := c.Authenticate(); err != nil {
-			return nil, fmt.Errorf("re-authentication failed: %w", err)
+	if slots := c.requestSlots; slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			cancel()
+			return nil, nil, fmt.Errorf("request cancelled while waiting for a free request slot: %w", ctx.Err())
+		}
+		var release sync.Once
+		cancelRequest := cancel
+		cancel = func() {
+			cancelRequest()
+			release.Do(func() { <-slots })
 		}
-		// Retry the request
-		return c.DoRequest(method, path, body)
 	}
 
-	return resp, nil
+	resp, err := c.handler()(req)
+	if err != nil {
+		cancel()
+		return req, nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	// Keep the deadline alive until the caller has finished with the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return req, resp, nil
+}
+
+// cancelOnClose releases a request's context when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the request context
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
 }
 
 // Todo represents a todo item
@@ -133,95 +781,439 @@ type Todo struct {
 	Completed   bool   `json:"completed"`
 	CreatedAt   string `json:"createdAt,omitempty"`
 	UpdatedAt   string `json:"updatedAt,omitempty"`
+
+	// CompletedAt is when the todo was completed, if the API records it
+	CompletedAt string `json:"completedAt,omitempty"`
+
+	// ParentID is the id of the todo this one is a subtask of, if any
+	ParentID string `json:"parentId,omitempty"`
+
+	// ModifiedBy is the id of the user who last changed the todo. Older
+	// backends don't track it and leave it empty.
+	ModifiedBy string `json:"modifiedBy,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Warnings holds non-fatal feedback the server may include when a
+	// todo is created or updated
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Version increases with every change on APIs that version todos, sent
+	// as "version" or "generation". Zero when the API doesn't.
+	Version int `json:"version,omitempty"`
+
+	// ETag is the entity tag the todo was served with, if the API sent one
+	ETag string `json:"-"`
+
+	// Raw is the todo's JSON object as the server sent it, compacted,
+	// including any fields not modelled here. Empty when the todo was not
+	// decoded from a response.
+	Raw json.RawMessage `json:"-"`
+
+	// fields are the JSON fields present in the response, see HasField
+	fields map[string]struct{}
+}
+
+// TodoInput holds the writable fields of a todo. Nil fields are left out of
+// the request so the server keeps (or, on create, defaults) their values.
+type TodoInput struct {
+	Title       *string
+	Description *string
+	Completed   *bool
+
+	// CompletedAt, an RFC 3339 timestamp, backdates completion. It is sent
+	// in the same request as Completed so both change together; when nil
+	// the server records the time itself.
+	CompletedAt *string
+
+	// Metadata replaces the todo's metadata when non-nil; an empty map clears it
+	Metadata map[string]string
+
+	// ParentID makes the todo a subtask of another. Only honoured on
+	// create; use SetTodoParent to move an existing todo.
+	ParentID *string
+
+	// Version is the todo version an update is based on, for optimistic
+	// concurrency. The API rejects the update with 409 Conflict if the todo
+	// has changed since, which is reported as ErrTodoChanged. Ignored on
+	// create.
+	Version *int
+}
+
+// todoBody builds the JSON request body for the input
+func (c *Client) todoBody(in TodoInput) map[string]interface{} {
+	body := make(map[string]interface{})
+	if in.Title != nil {
+		body["title"] = *in.Title
+	}
+	if in.Description != nil {
+		body["description"] = *in.Description
+	}
+	if in.Completed != nil {
+		c.CompletedEncoding.encode(body, *in.Completed)
+	}
+	if in.CompletedAt != nil {
+		body["completedAt"] = *in.CompletedAt
+	}
+	if in.Metadata != nil {
+		body["metadata"] = in.Metadata
+	}
+	if in.ParentID != nil {
+		body["parentId"] = *in.ParentID
+	}
+	if in.Version != nil {
+		body["version"] = *in.Version
+	}
+	return body
 }
 
 // CreateTodo creates a new todo
-func (c *Client) CreateTodo(title, description string, completed bool) (*Todo, error) {
-	todo := map[string]interface{}{
-		"title":       title,
-		"description": description,
-		"completed":   completed,
+func (c *Client) CreateTodo(ctx context.Context, input TodoInput) (*Todo, error) {
+	todo, _, err := c.createTodo(ctx, c.todoBody(input), RequestOptions{})
+	return todo, err
+}
+
+// CreateTodoIdempotent creates a new todo, sending key as an Idempotency-Key
+// header so the request can safely be retried. The returned bool is false
+// when the server recognised the key and returned a todo created by an
+// earlier attempt instead of creating a new one.
+func (c *Client) CreateTodoIdempotent(ctx context.Context, key string, input TodoInput) (*Todo, bool, error) {
+	opts := RequestOptions{
+		Idempotency: Idempotent,
+		Headers:     http.Header{idempotencyKeyHeader: []string{key}},
 	}
+	return c.createTodo(ctx, c.todoBody(input), opts)
+}
 
-	resp, err := c.DoRequest("POST", "/todos", todo)
+// createTodo posts a new todo and reports whether the server created it
+// (201) rather than returning an existing one (200)
+func (c *Client) createTodo(ctx context.Context, body map[string]interface{}, opts RequestOptions) (*Todo, bool, error) {
+	opts.Operation = "create todo"
+	resp, err := c.DoRequestWithOptions(ctx, "POST", "/todos", body, c.writeOptions(opts))
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("create todo failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	if c.isAccepted(resp) {
+		todo, err := c.awaitTodo(ctx, "create todo", "", resp)
+		return todo, true, err
 	}
 
-	var createdTodo Todo
-	if err := json.NewDecoder(resp.Body).Decode(&createdTodo); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return nil, false, newAPIError("create todo", resp)
 	}
 
-	return &createdTodo, nil
+	var createdTodo *Todo
+	if isMinimalResponse(resp) {
+		createdTodo, err = c.fetchWrittenTodo(ctx, "create todo", "", resp)
+		if err != nil {
+			return nil, false, err
+		}
+	} else {
+		createdTodo = &Todo{}
+		if err := c.decodeJSON(resp.Body, createdTodo); err != nil {
+			return nil, false, fmt.Errorf("failed to decode response: %w", err)
+		}
+		createdTodo.ETag = resp.Header.Get("ETag")
+	}
+
+	// Servers that honour idempotency keys either answer a replay with 200
+	// instead of 201, or echo the key back on the response
+	created := true
+	if key := opts.Headers.Get(idempotencyKeyHeader); key != "" {
+		replayed := resp.StatusCode == http.StatusOK || resp.Header.Get(idempotencyKeyHeader) == key
+		created = !replayed
+		if replayed {
+			tflog.Debug(ctx, "Server returned existing todo for idempotency key", map[string]any{"id": createdTodo.ID})
+		}
+	}
+
+	return createdTodo, created, nil
 }
 
+// ErrNotFound is returned, possibly wrapped, when the API reports a todo
+// does not exist. DeleteTodo treats a missing todo as already deleted
+// instead.
+var ErrNotFound = errors.New("todo not found")
+
+// notFoundConfirmDelay is how long GetTodo waits before re-checking a 404
+// when ConfirmNotFound is enabled
+const notFoundConfirmDelay = 2 * time.Second
+
 // GetTodo retrieves a todo by ID
-func (c *Client) GetTodo(id string) (*Todo, error) {
-	resp, err := c.DoRequest("GET", "/todos/"+id, nil)
+func (c *Client) GetTodo(ctx context.Context, id string) (*Todo, error) {
+	return c.GetTodoFields(ctx, id, nil)
+}
+
+// GetTodoFields retrieves a todo by ID like GetTodo, asking the API to send
+// only the named JSON fields (e.g. "title", "completed") to cut the payload
+// of large refreshes. The id is always requested. APIs without sparse
+// fieldsets send the whole todo, so callers must not rely on other fields
+// being absent; use HasField to see which were sent. Sparse reads bypass
+// the read cache. With no fields the whole todo is requested.
+func (c *Client) GetTodoFields(ctx context.Context, id string, fields []string) (*Todo, error) {
+	if err := validateTodoID(id); err != nil {
+		return nil, err
+	}
+
+	var todo *Todo
+	fetch := func() (err error) {
+		todo, err = c.getTodo(ctx, id, fields)
+		return err
+	}
+	err := c.refetchOnDecodeError(ctx, "/todos/"+id, fetch)
+	if errors.Is(err, ErrNotFound) && c.ConfirmNotFound {
+		// A single 404 may be a transient backend inconsistency, so look
+		// again before reporting the todo as gone
+		tflog.Debug(ctx, "Todo not found, re-checking before treating it as deleted", map[string]any{"id": id})
+		if err := sleepContext(ctx, notFoundConfirmDelay); err != nil {
+			return nil, err
+		}
+		err = c.refetchOnDecodeError(ctx, "/todos/"+id, fetch)
+	}
+
+	return todo, err
+}
+
+// getTodo makes a single attempt to retrieve a todo by ID, consulting the
+// read cache first when it is enabled and the whole todo is wanted
+func (c *Client) getTodo(ctx context.Context, id string, fields []string) (*Todo, error) {
+	if len(fields) > 0 {
+		return c.fetchTodo(ctx, "/todos/"+id+"?fields="+c.fieldsParam(fields), RequestOptions{})
+	}
+
+	var cached *Todo
+	var cachedETag string
+	opts := RequestOptions{Operation: "get todo"}
+	if c.readCache != nil {
+		todo, fresh, etag := c.readCache.get(id)
+		if fresh {
+			tflog.Debug(ctx, "Serving todo from read cache", map[string]any{"id": id})
+			return todo, nil
+		}
+		if todo != nil {
+			cached, cachedETag = todo, etag
+			opts.Headers = http.Header{"If-None-Match": []string{etag}}
+		}
+	}
+
+	resp, err := c.DoRequestWithOptions(ctx, "GET", "/todos/"+id, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		// A 304 naming another ETag did not revalidate the cached copy, so
+		// drop it and fetch the todo unconditionally
+		if etag := resp.Header.Get("ETag"); etag != "" && !etagWeakMatch(etag, cachedETag) {
+			tflog.Debug(ctx, "Not Modified response named a different ETag, refetching", map[string]any{"id": id})
+			c.readCache.invalidate(id)
+			return c.getTodo(ctx, id, fields)
+		}
+
+		tflog.Debug(ctx, "Cached todo revalidated", map[string]any{"id": id})
+		c.readCache.touch(id)
+		return cached, nil
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		if c.readCache != nil {
+			c.readCache.invalidate(id)
+		}
+		return nil, ErrNotFound
+	}
+
+	todo, err := c.decodeTodo(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.readCache != nil && todo.ID != "" {
+		c.readCache.put(todo, todo.ETag)
+	}
+
+	return todo, nil
+}
+
+// fetchTodo makes a single uncached GET of the todo at path
+func (c *Client) fetchTodo(ctx context.Context, path string, opts RequestOptions) (*Todo, error) {
+	opts.Operation = "get todo"
+	resp, err := c.DoRequestWithOptions(ctx, "GET", path, nil, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("todo not found")
+		return nil, ErrNotFound
 	}
 
+	return c.decodeTodo(resp)
+}
+
+// decodeTodo decodes the todo in a successful get response
+func (c *Client) decodeTodo(resp *http.Response) (*Todo, error) {
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get todo failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError("get todo", resp)
 	}
 
 	var todo Todo
-	if err := json.NewDecoder(resp.Body).Decode(&todo); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.decodeJSON(resp.Body, &todo); err != nil {
+		return nil, &decodeError{err: err}
 	}
+	todo.ETag = resp.Header.Get("ETag")
 
 	return &todo, nil
 }
 
-// UpdateTodo updates a todo
-func (c *Client) UpdateTodo(id string, title, description *string, completed *bool) (*Todo, error) {
-	updates := make(map[string]interface{})
-	if title != nil {
-		updates["title"] = *title
+// fieldsParam builds the value of the fields query parameter, always
+// including the id and naming completion as CompletedEncoding sends it
+func (c *Client) fieldsParam(fields []string) string {
+	names := []string{"id"}
+	for _, field := range fields {
+		if field == "completed" && c.CompletedEncoding == CompletedEncodingStatus {
+			field = "status"
+		}
+		if field != "id" {
+			names = append(names, url.QueryEscape(field))
+		}
 	}
-	if description != nil {
-		updates["description"] = *description
+	return strings.Join(names, ",")
+}
+
+// UpdateTodo updates the fields of a todo that are set in input
+func (c *Client) UpdateTodo(ctx context.Context, id string, input TodoInput) (*Todo, error) {
+	if err := validateTodoID(id); err != nil {
+		return nil, err
 	}
-	if completed != nil {
-		updates["completed"] = *completed
+
+	if c.readCache != nil {
+		defer c.readCache.invalidate(id)
 	}
 
-	resp, err := c.DoRequest("PUT", "/todos/"+id, updates)
+	if c.updateBatcher != nil {
+		return c.updateBatcher.submit(ctx, id, c.todoBody(input))
+	}
+
+	return c.updateTodo(ctx, id, c.todoBody(input))
+}
+
+// updateTodo sends a single todo update
+func (c *Client) updateTodo(ctx context.Context, id string, body map[string]interface{}) (*Todo, error) {
+	resp, err := c.DoRequestWithOptions(ctx, "PUT", "/todos/"+id, body, c.writeOptions(RequestOptions{Operation: "update todo"}))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("update todo failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	if c.isAccepted(resp) {
+		return c.awaitTodo(ctx, "update todo", id, resp)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent, resp.StatusCode == http.StatusOK && isMinimalResponse(resp):
+		return c.fetchWrittenTodo(ctx, "update todo", id, resp)
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, newAPIError("update todo", resp))
+	case resp.StatusCode == http.StatusConflict && body["version"] != nil:
+		// Only a versioned update can be stale; other conflicts pass through
+		return nil, fmt.Errorf("%w: %w", ErrTodoChanged, newAPIError("update todo", resp))
+	case resp.StatusCode != http.StatusOK:
+		return nil, newAPIError("update todo", resp)
 	}
 
 	var updatedTodo Todo
-	if err := json.NewDecoder(resp.Body).Decode(&updatedTodo); err != nil {
+	if err := c.decodeJSON(resp.Body, &updatedTodo); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	updatedTodo.ETag = resp.Header.Get("ETag")
 
 	return &updatedTodo, nil
 }
 
+// SetTodoParent moves a todo under parentID, or detaches it from its parent
+// when parentID is empty
+func (c *Client) SetTodoParent(ctx context.Context, id, parentID string) (*Todo, error) {
+	if err := validateTodoID(id); err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		if err := validateTodoID(parentID); err != nil {
+			return nil, err
+		}
+	}
+	if parentID == id {
+		return nil, fmt.Errorf("todo %s cannot be its own parent", id)
+	}
+
+	if c.readCache != nil {
+		defer c.readCache.invalidate(id)
+	}
+
+	body := map[string]interface{}{"parentId": nil}
+	if parentID != "" {
+		body["parentId"] = parentID
+	}
+
+	// Setting a single field to a fixed value is safe to repeat
+	resp, err := c.DoRequestWithOptions(ctx, "PATCH", "/todos/"+id, body, RequestOptions{Idempotency: Idempotent, Operation: "set todo parent"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, newAPIError("set todo parent", resp))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("set todo parent", resp)
+	}
+
+	var todo Todo
+	if err := c.decodeJSON(resp.Body, &todo); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	todo.ETag = resp.Header.Get("ETag")
+
+	return &todo, nil
+}
+
+// ErrTodoChanged is returned by DeleteTodoIfMatch when the todo was modified
+// after the ETag it was given, and by UpdateTodo when it was modified after
+// the Version it was given
+var ErrTodoChanged = errors.New("todo changed since it was last read")
+
 // DeleteTodo deletes a todo
-func (c *Client) DeleteTodo(id string) error {
-	resp, err := c.DoRequest("DELETE", "/todos/"+id, nil)
+func (c *Client) DeleteTodo(ctx context.Context, id string) error {
+	return c.deleteTodo(ctx, id, RequestOptions{})
+}
+
+// DeleteTodoIfMatch deletes a todo only if it is unchanged since it was
+// served with etag, returning ErrTodoChanged otherwise. Without a strong
+// etag to compare against it deletes unconditionally.
+func (c *Client) DeleteTodoIfMatch(ctx context.Context, id, etag string) error {
+	opts := RequestOptions{}
+	if ifMatch := ifMatchValue(etag); ifMatch != "" {
+		opts.Headers = http.Header{"If-Match": []string{ifMatch}}
+	}
+	return c.deleteTodo(ctx, id, opts)
+}
+
+// deleteTodo sends a todo delete
+func (c *Client) deleteTodo(ctx context.Context, id string, opts RequestOptions) error {
+	if err := validateTodoID(id); err != nil {
+		return err
+	}
+
+	if c.readCache != nil {
+		defer c.readCache.invalidate(id)
+	}
+
+	opts.Operation = "delete todo"
+	resp, err := c.DoRequestWithOptions(ctx, "DELETE", "/todos/"+id, nil, opts)
 	if err != nil {
 		return err
 	}
@@ -232,9 +1224,12 @@ func (c *Client) DeleteTodo(id string) error {
 		return nil
 	}
 
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return ErrTodoChanged
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("delete todo failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+		return newAPIError("delete todo", resp)
 	}
 
 	return nil
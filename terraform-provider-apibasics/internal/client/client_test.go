@@ -0,0 +1,387 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRedirectToSameHostKeepsAuthorization(t *testing.T) {
+	target := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want the original token", got)
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Redirected"})
+	})
+	// Both servers listen on 127.0.0.1, so only the port changes
+	origin := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL+r.URL.Path, http.StatusMovedPermanently)
+	})
+	c := newTestClient(origin)
+
+	todo, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if todo.Title != "Redirected" {
+		t.Errorf("title = %q, want %q", todo.Title, "Redirected")
+	}
+}
+
+func TestRedirectToAnotherHostIsRefused(t *testing.T) {
+	target := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request followed to another host with Authorization %q", r.Header.Get("Authorization"))
+	})
+	otherHost := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+	origin := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, otherHost+r.URL.Path, http.StatusFound)
+	})
+	c := newTestClient(origin)
+	c.MaxRetries = 0
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	if err == nil || !strings.Contains(err.Error(), "different host") {
+		t.Fatalf("GetTodo() error = %v, want a cross-host redirect error", err)
+	}
+}
+
+func TestUpdateTodoSendsOnlySetFields(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		if want := map[string]interface{}{"title": "Renamed"}; !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v, want %v", body, want)
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Renamed", Description: "kept"})
+	})
+	c := newTestClient(srv)
+
+	title := "Renamed"
+	todo, err := c.UpdateTodo(context.Background(), testTodoID, TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if todo.Description != "kept" {
+		t.Errorf("description = %q, want the server's value", todo.Description)
+	}
+}
+
+func TestForceReauthenticateLogsInAgain(t *testing.T) {
+	var issuer tokenIssuer
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		issuer.serveToken(t, w, r)
+	})
+	c := NewClient(srv.URL, "ada@example.com", "secret")
+
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	first := c.accessToken()
+
+	if err := c.ForceReauthenticate(context.Background()); err != nil {
+		t.Fatalf("ForceReauthenticate() error = %v", err)
+	}
+	if c.accessToken() == first {
+		t.Errorf("access token still %q after ForceReauthenticate", first)
+	}
+	if c.RefreshToken != "refresh-2" {
+		t.Errorf("refresh token = %q, want the one from the new login", c.RefreshToken)
+	}
+	// The old refresh token may be revoked too, so it must not be used
+	if logins, refreshes := issuer.counts(); logins != 2 || refreshes != 0 {
+		t.Errorf("logins = %d, refreshes = %d, want 2 and 0", logins, refreshes)
+	}
+}
+
+// stallFirst returns a handler that stalls the first n requests until the
+// client gives up on them and answers the rest with a todo
+func stallFirst(t *testing.T, n int32, calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Eventually"})
+	}
+}
+
+func TestPerRequestTimeoutRetriesWithFreshDeadline(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(newTestServer(t, stallFirst(t, 1, &calls)))
+	c.PerRequestTimeout = 50 * time.Millisecond
+	c.MaxRetries = 2
+
+	todo, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if todo.Title != "Eventually" || calls.Load() != 2 {
+		t.Errorf("title = %q after %d attempts, want %q after 2", todo.Title, calls.Load(), "Eventually")
+	}
+}
+
+func TestCallerDeadlineBoundsAllAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(newTestServer(t, stallFirst(t, 100, &calls)))
+	c.PerRequestTimeout = 40 * time.Millisecond
+	c.MaxRetries = 50
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetTodo(ctx, testTodoID)
+	if err == nil {
+		t.Fatal("GetTodo() succeeded, want the caller's deadline to stop it")
+	}
+	// Each attempt gets its own 40ms, but the retries stop at the caller's 100ms
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetTodo() took %v, want it bounded by the caller's deadline", elapsed)
+	}
+	if n := calls.Load(); n < 2 || n > 4 {
+		t.Errorf("attempts = %d, want each bounded by PerRequestTimeout within the caller's deadline", n)
+	}
+}
+
+func TestCreateTodoWithoutDescriptionLeavesServerDefault(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		if _, ok := body["description"]; ok {
+			t.Errorf("body sent description %v, want it omitted", body["description"])
+		}
+		writeJSON(t, w, http.StatusCreated, Todo{ID: testTodoID, Title: "Templated", Description: "## Acceptance criteria"})
+	})
+	c := newTestClient(srv)
+
+	title := "Templated"
+	todo, err := c.CreateTodo(context.Background(), TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+	if todo.Description != "## Acceptance criteria" {
+		t.Errorf("description = %q, want the server's template", todo.Description)
+	}
+}
+
+func TestAuthenticateUsesTokenPath(t *testing.T) {
+	var issuer tokenIssuer
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/auth/login" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		issuer.serveToken(t, w, r)
+	})
+	c := NewClient(srv.URL, "ada@example.com", "secret")
+	c.TokenPath = "/auth/login"
+
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if c.accessToken() != "token-1" {
+		t.Errorf("access token = %q, want %q", c.accessToken(), "token-1")
+	}
+}
+
+func TestCreateTodoDecodesWarnings(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusCreated, map[string]interface{}{
+			"id":       testTodoID,
+			"title":    strings.Repeat("long ", 40),
+			"warnings": []string{"title is very long"},
+		})
+	})
+	c := newTestClient(srv)
+
+	title := strings.Repeat("long ", 40)
+	todo, err := c.CreateTodo(context.Background(), TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v, want warnings to be non-fatal", err)
+	}
+	if len(todo.Warnings) != 1 || todo.Warnings[0] != "title is very long" {
+		t.Errorf("warnings = %v, want the server's warning", todo.Warnings)
+	}
+}
+
+func TestForbiddenDoesNotReauthenticate(t *testing.T) {
+	var issuer tokenIssuer
+	var calls atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == DefaultTokenPath || r.URL.Path == DefaultRefreshPath {
+			issuer.serveToken(t, w, r)
+			return
+		}
+		calls.Add(1)
+		writeJSON(t, w, http.StatusForbidden, map[string]string{"error": "not allowed"})
+	})
+	c := newTestClient(srv)
+	c.Email, c.Password = "ada@example.com", "secret"
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	if !IsForbidden(err) {
+		t.Fatalf("GetTodo() error = %v, want a 403 APIError", err)
+	}
+	if logins, refreshes := issuer.counts(); logins != 0 || refreshes != 0 {
+		t.Errorf("logins = %d, refreshes = %d, want no re-authentication on 403", logins, refreshes)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestUpdateTodoSendsCompletionTogether(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		want := map[string]interface{}{"completed": true, "completedAt": "2024-05-01T12:00:00Z"}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v, want %v in one request", body, want)
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Completed: true, CompletedAt: "2024-05-01T12:00:00Z"})
+	})
+	c := newTestClient(srv)
+
+	completed, completedAt := true, "2024-05-01T12:00:00Z"
+	todo, err := c.UpdateTodo(context.Background(), testTodoID, TodoInput{Completed: &completed, CompletedAt: &completedAt})
+	if err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if !todo.Completed || todo.CompletedAt != completedAt {
+		t.Errorf("todo = %+v, want it completed at %s", todo, completedAt)
+	}
+}
+
+func TestLimitConcurrentRequestsCapsInFlight(t *testing.T) {
+	const limit = 3
+	var inFlight, peak atomic.Int32
+	c := newTestClient(newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+	}))
+	c.LimitConcurrentRequests(limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+				t.Errorf("GetTodo() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak in-flight requests = %d, want at most %d", got, limit)
+	}
+}
+
+func TestLimitConcurrentRequestsWaitRespectsContext(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(newTestServer(t, stallFirst(t, 1, &calls)))
+	c.LimitConcurrentRequests(1)
+
+	// Hold the only slot with a request the server stalls
+	holder, cancelHolder := context.WithCancel(context.Background())
+	defer cancelHolder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.GetTodo(holder, testTodoID)
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetTodo(ctx, testTodoID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetTodo() error = %v, want the context deadline while waiting for a slot", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want only the one holding the slot", got)
+	}
+
+	cancelHolder()
+	<-done
+}
+
+func TestClientsForDifferentEndpointsDoNotInterfere(t *testing.T) {
+	newEndpoint := func(name string) *Client {
+		var issuer tokenIssuer
+		srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == DefaultTokenPath {
+				issuer.serveToken(t, w, r)
+				return
+			}
+			if got := r.Header.Get("Authorization"); got != "Bearer token-1" {
+				t.Errorf("%s got Authorization %q, want its own token", name, got)
+			}
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: name})
+		})
+		return NewClient(srv.URL, name+"@example.com", "secret")
+	}
+	staging, prod := newEndpoint("staging"), newEndpoint("prod")
+
+	for _, c := range []*Client{staging, prod} {
+		if err := c.Authenticate(context.Background()); err != nil {
+			t.Fatalf("Authenticate() error = %v", err)
+		}
+	}
+	for name, c := range map[string]*Client{"staging": staging, "prod": prod} {
+		todo, err := c.GetTodo(context.Background(), testTodoID)
+		if err != nil {
+			t.Fatalf("%s GetTodo() error = %v", name, err)
+		}
+		if todo.Title != name {
+			t.Errorf("%s client reached %s", name, todo.Title)
+		}
+	}
+}
+
+func TestWithBaseURLCopiesSettingsWithoutSharingThem(t *testing.T) {
+	c := NewClient("https://staging.example.com", "ada@example.com", "secret")
+	c.AccessToken = "staging-token"
+	c.MaxRetries = 7
+	c.RetryableErrorMessages = []string{"try again"}
+
+	clone := c.WithBaseURL("https://prod.example.com")
+	if clone.BaseURL != "https://prod.example.com" || clone.MaxRetries != 7 || clone.Email != c.Email {
+		t.Errorf("clone = %+v, want the settings of c with the new base URL", clone)
+	}
+	if clone.AccessToken != "" {
+		t.Errorf("clone access token = %q, want none until it authenticates", clone.AccessToken)
+	}
+
+	clone.RetryableErrorMessages[0] = "changed"
+	if c.RetryableErrorMessages[0] != "try again" {
+		t.Errorf("changing the clone's settings changed the original to %q", c.RetryableErrorMessages[0])
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import "time"
+
+// TodosEqual reports whether two todos have the same user-settable
+// content: title, description, completion, parent and metadata. Fields the
+// server manages, such as the id, owner, version and creation and update
+// times, are ignored. Nil and empty metadata are equal, and completion
+// times are compared as instants, so differently formatted timestamps for
+// the same moment match. A completion time is only compared when both
+// todos have one, since the server records it when none is given.
+func TodosEqual(a, b Todo) bool {
+	return len(changedTodoFields(a, b)) == 0
+}
+
+// changedTodoFields returns the JSON names of the fields TodosEqual
+// compares that differ between current and desired
+func changedTodoFields(current, desired Todo) []string {
+	var fields []string
+	if current.Title != desired.Title {
+		fields = append(fields, "title")
+	}
+	if current.Description != desired.Description {
+		fields = append(fields, "description")
+	}
+	if current.Completed != desired.Completed {
+		fields = append(fields, "completed")
+	}
+	if current.CompletedAt != "" && desired.CompletedAt != "" && !sameInstant(current.CompletedAt, desired.CompletedAt) {
+		fields = append(fields, "completedAt")
+	}
+	if current.ParentID != desired.ParentID {
+		fields = append(fields, "parentId")
+	}
+	if !metadataEqual(current.Metadata, desired.Metadata) {
+		fields = append(fields, "metadata")
+	}
+	return fields
+}
+
+// sameInstant reports whether two timestamps denote the same moment,
+// falling back to comparing the text when either cannot be parsed
+func sameInstant(a, b string) bool {
+	ta, errA := time.Parse(time.RFC3339Nano, a)
+	tb, errB := time.Parse(time.RFC3339Nano, b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ta.Equal(tb)
+}
+
+// metadataEqual reports whether two metadata maps hold the same pairs,
+// treating nil and empty as equal
+func metadataEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package client
+
+import "testing"
+
+func TestTodosEqual(t *testing.T) {
+	base := Todo{
+		ID:          testTodoID,
+		Title:       "Write tests",
+		Completed:   true,
+		CompletedAt: "2024-05-01T12:00:00Z",
+		Metadata:    map[string]string{"team": "core"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Todo)
+		equal  bool
+	}{
+		{name: "identical", modify: func(*Todo) {}, equal: true},
+		{name: "server fields", modify: func(td *Todo) {
+			td.ID = testTodoID2
+			td.UserID = testUserID
+			td.Version = 3
+			td.UpdatedAt = "2024-05-02T00:00:00Z"
+		}, equal: true},
+		{name: "same instant in another offset", modify: func(td *Todo) { td.CompletedAt = "2024-05-01T14:00:00+02:00" }, equal: true},
+		{name: "completion time missing", modify: func(td *Todo) { td.CompletedAt = "" }, equal: true},
+		{name: "empty metadata", modify: func(td *Todo) { td.Metadata = nil }},
+		{name: "title", modify: func(td *Todo) { td.Title = "Write more tests" }},
+		{name: "description", modify: func(td *Todo) { td.Description = "all of them" }},
+		{name: "completed", modify: func(td *Todo) { td.Completed = false }},
+		{name: "completion time", modify: func(td *Todo) { td.CompletedAt = "2024-05-01T13:00:00Z" }},
+		{name: "parent", modify: func(td *Todo) { td.ParentID = testTodoID2 }},
+		{name: "metadata value", modify: func(td *Todo) { td.Metadata = map[string]string{"team": "docs"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			tt.modify(&other)
+			if got := TodosEqual(base, other); got != tt.equal {
+				t.Errorf("TodosEqual() = %v, want %v", got, tt.equal)
+			}
+		})
+	}
+}
+
+func TestTodosEqualNilAndEmptyMetadata(t *testing.T) {
+	if !TodosEqual(Todo{Title: "a"}, Todo{Title: "a", Metadata: map[string]string{}}) {
+		t.Error("nil and empty metadata should be equal")
+	}
+}
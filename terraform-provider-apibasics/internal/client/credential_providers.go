@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the access token sent with each request, for
+// sources other than the built-in email and password login, such as Vault
+// or a rotating secret. Token is called once per request, so providers
+// should cache tokens themselves; an empty token sends no Authorization
+// header. It may be called from several goroutines at once.
+type CredentialProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// ErrCredentialProvider wraps an error returned by a CredentialProvider's
+// Token. The request was never sent and the failure lies with the
+// provider's source, so DefaultShouldRetry does not retry it.
+var ErrCredentialProvider = errors.New("failed to get access token")
+
+// TokenInvalidator is implemented by credential providers that cache
+// tokens. When the API rejects a request with a 401, the client calls
+// InvalidateToken with the token that was sent, so the next Token call
+// fetches a new one, and retries the request once. Without it a 401 is
+// returned to the caller as is.
+type TokenInvalidator interface {
+	InvalidateToken(token string)
+}
+
+// StaticToken is a CredentialProvider that always returns the same token
+type StaticToken string
+
+// Token implements CredentialProvider
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// PasswordGrant returns a CredentialProvider that logs in with c's email
+// and password when it has no token, and again whenever the API rejects
+// it. It shares c's tokens, as used when Credentials is nil, so concurrent
+// logins are coalesced the same way.
+func (c *Client) PasswordGrant() CredentialProvider {
+	return passwordGrant{client: c}
+}
+
+// passwordGrant implements PasswordGrant
+type passwordGrant struct {
+	client *Client
+}
+
+// Token implements CredentialProvider
+func (p passwordGrant) Token(ctx context.Context) (string, error) {
+	if token := p.client.accessToken(); token != "" {
+		return token, nil
+	}
+	if err := p.client.reauthenticate(ctx, ""); err != nil {
+		return "", err
+	}
+	return p.client.accessToken(), nil
+}
+
+// InvalidateToken implements TokenInvalidator
+func (p passwordGrant) InvalidateToken(token string) {
+	c := p.client
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.AccessToken == token {
+		c.AccessToken = ""
+	}
+}
+
+// tokenExpiryMargin is how long before its reported expiry a cached token
+// is replaced, so it does not expire while a request is in flight
+const tokenExpiryMargin = 30 * time.Second
+
+// ClientCredentials is a CredentialProvider for the OAuth 2.0 client
+// credentials grant, for service accounts. Tokens are cached until shortly
+// before they expire, or until the API rejects them.
+type ClientCredentials struct {
+	// TokenURL is the absolute URL of the token endpoint
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+
+	// Scopes are requested with each token, if any
+	Scopes []string
+
+	// HTTPClient sends the token requests. Nil uses http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// Token implements CredentialProvider
+func (p *ClientCredentials) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && (p.expiry.IsZero() || time.Now().Before(p.expiry)) {
+		return p.token, nil
+	}
+
+	tokenResp, err := p.requestToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = tokenResp.AccessToken
+	p.expiry = time.Time{}
+	if tokenResp.ExpiresIn > 0 {
+		p.expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - tokenExpiryMargin)
+	}
+	return p.token, nil
+}
+
+// InvalidateToken implements TokenInvalidator
+func (p *ClientCredentials) InvalidateToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == token {
+		p.token = ""
+	}
+}
+
+// requestToken exchanges the client id and secret for a token
+func (p *ClientCredentials) requestToken(ctx context.Context) (*TokenResponse, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("client credentials", resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var tokenResp TokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not include an access token")
+	}
+
+	return &tokenResp, nil
+}
+
+// sentTokenKey is the context key for the slot authMiddleware records the
+// token it sent in, so a rejected token can be invalidated
+type sentTokenKey struct{}
+
+// withSentTokenSlot returns a context in which authMiddleware records the
+// token it sends into *slot
+func withSentTokenSlot(ctx context.Context, slot *string) context.Context {
+	return context.WithValue(ctx, sentTokenKey{}, slot)
+}
+
+// recordSentToken stores token in the slot of ctx, if it has one
+func recordSentToken(ctx context.Context, token string) {
+	if slot, ok := ctx.Value(sentTokenKey{}).(*string); ok {
+		*slot = token
+	}
+}
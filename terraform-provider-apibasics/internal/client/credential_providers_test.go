@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// rotatingCredentials is a custom CredentialProvider that hands out
+// numbered tokens, moving to the next one when a token is invalidated
+type rotatingCredentials struct {
+	mu          sync.Mutex
+	version     int
+	invalidated []string
+}
+
+func (p *rotatingCredentials) Token(context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return "v" + strconv.Itoa(p.version), nil
+}
+
+func (p *rotatingCredentials) InvalidateToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.invalidated = append(p.invalidated, token)
+	p.version++
+}
+
+// acceptToken returns a handler that accepts only the given bearer token
+func acceptToken(t *testing.T, token string, calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+	}
+}
+
+func TestCustomCredentialProviderIsInvalidatedOnce(t *testing.T) {
+	var calls atomic.Int32
+	c := NewClient(newTestServer(t, acceptToken(t, "v1", &calls)).URL, "", "")
+	creds := &rotatingCredentials{}
+	c.Credentials = creds
+
+	if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if calls.Load() != 2 || len(creds.invalidated) != 1 || creds.invalidated[0] != "v0" {
+		t.Errorf("calls = %d, invalidated = %q, want the rejected v0 replaced by one retry", calls.Load(), creds.invalidated)
+	}
+
+	// A provider whose new token is rejected too is not asked again
+	creds.version = 5
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GetTodo() error = %v, want the 401", err)
+	}
+	if len(creds.invalidated) != 2 {
+		t.Errorf("invalidated = %q, want a single retry", creds.invalidated)
+	}
+}
+
+func TestCredentialProviderWithoutInvalidatorReturns401(t *testing.T) {
+	var calls atomic.Int32
+	c := NewClient(newTestServer(t, acceptToken(t, "other", &calls)).URL, "", "")
+	c.Credentials = StaticToken("static")
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GetTodo() error = %v, want the 401", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want no retry", calls.Load())
+	}
+}
+
+// failingCredentials is a CredentialProvider whose source is unavailable
+type failingCredentials struct {
+	calls *atomic.Int32
+}
+
+var errVaultSealed = errors.New("vault is sealed")
+
+func (p failingCredentials) Token(context.Context) (string, error) {
+	p.calls.Add(1)
+	return "", errVaultSealed
+}
+
+func TestCredentialProviderErrorStopsRequest(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	c := NewClient(srv.URL, "", "")
+	var calls atomic.Int32
+	c.Credentials = failingCredentials{calls: &calls}
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	if !errors.Is(err, errVaultSealed) || !errors.Is(err, ErrCredentialProvider) {
+		t.Errorf("GetTodo() error = %v, want the provider's error", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Token called %d times, want no retry", calls.Load())
+	}
+}
+
+func TestClientCredentialsCachesToken(t *testing.T) {
+	var tokens atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			tokens.Add(1)
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "svc" || secret != "s3cret" {
+				t.Errorf("basic auth = %q, %q, want the client id and secret", id, secret)
+			}
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "todos:read todos:write" {
+				t.Errorf("form = %v, want the client credentials grant and scopes", r.PostForm)
+			}
+			writeJSON(t, w, http.StatusOK, TokenResponse{TokenType: "Bearer", AccessToken: "svc-token", ExpiresIn: 3600})
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer svc-token" {
+			t.Errorf("Authorization = %q, want the service token", got)
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+	})
+	c := NewClient(srv.URL, "", "")
+	c.Credentials = &ClientCredentials{
+		TokenURL:     srv.URL + "/oauth/token",
+		ClientID:     "svc",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"todos:read", "todos:write"},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+			t.Fatalf("GetTodo() error = %v", err)
+		}
+	}
+	if got := tokens.Load(); got != 1 {
+		t.Errorf("token requests = %d, want 1 shared by both requests", got)
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidCredentials is returned by ValidateCredentials when the API
+// rejects the credentials, as opposed to the check itself failing
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// ValidateCredentials checks that the configured credentials are accepted by
+// the API without changing the client's stored tokens. With an email and
+// password it logs in and discards the tokens; with only an access token,
+// or one from Credentials, it makes a read-only profile request. Rejected
+// credentials (401 or 403) are reported as ErrInvalidCredentials; any other
+// failure, such as a network error, is returned as is.
+func (c *Client) ValidateCredentials(ctx context.Context) error {
+	if c.Credentials != nil {
+		token, err := c.Credentials.Token(ctx)
+		if err != nil {
+			return classifyCredentialError(err)
+		}
+		return c.validateToken(ctx, token)
+	}
+	if c.Email == "" && c.Password == "" {
+		return c.validateToken(ctx, c.accessToken())
+	}
+
+	_, err := c.requestToken(ctx)
+	return classifyCredentialError(err)
+}
+
+// validateToken makes a single profile request with token. It bypasses
+// DoRequest so a 401 is reported rather than triggering re-authentication.
+func (c *Client) validateToken(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w: no email, password or access token configured", ErrInvalidCredentials)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/profile", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", c.authorization(token))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return classifyCredentialError(newAPIError("validate token", resp))
+	}
+	return nil
+}
+
+// classifyCredentialError wraps authentication failures with ErrInvalidCredentials
+func classifyCredentialError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	return err
+}
@@ -0,0 +1,288 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CompletedEncoding is the wire representation of a todo's completed flag
+type CompletedEncoding string
+
+const (
+	// CompletedEncodingBool sends completion as a boolean "completed" field
+	CompletedEncodingBool CompletedEncoding = "bool"
+
+	// CompletedEncodingStatus sends completion as a "status" field holding
+	// "done" or "open"
+	CompletedEncodingStatus CompletedEncoding = "status"
+)
+
+const (
+	statusDone = "done"
+	statusOpen = "open"
+)
+
+// encode writes completed into a request body using the encoding
+func (e CompletedEncoding) encode(body map[string]interface{}, completed bool) {
+	if e != CompletedEncodingStatus {
+		body["completed"] = completed
+		return
+	}
+
+	if completed {
+		body["status"] = statusDone
+	} else {
+		body["status"] = statusOpen
+	}
+}
+
+// UnmarshalJSON decodes a todo, accepting completion either as a boolean
+// "completed" field or as a "done"/"open" status string, whichever the
+// server sends, and timestamps in any format timestamp understands. It
+// records which fields were present for HasField and keeps the raw object.
+func (t *Todo) UnmarshalJSON(data []byte) error {
+	type todoAlias Todo
+	aux := struct {
+		*todoAlias
+		Status *string `json:"status"`
+
+		// Generation is an alternative name for version
+		Generation *int `json:"generation"`
+
+		// Shadow the string fields so any supported format is accepted
+		CreatedAt   timestamp `json:"createdAt"`
+		UpdatedAt   timestamp `json:"updatedAt"`
+		CompletedAt timestamp `json:"completedAt"`
+	}{
+		todoAlias:   (*todoAlias)(t),
+		CreatedAt:   timestamp(t.CreatedAt),
+		UpdatedAt:   timestamp(t.UpdatedAt),
+		CompletedAt: timestamp(t.CompletedAt),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.CreatedAt = string(aux.CreatedAt)
+	t.UpdatedAt = string(aux.UpdatedAt)
+	t.CompletedAt = string(aux.CompletedAt)
+	if aux.Generation != nil && t.Version == 0 {
+		t.Version = *aux.Generation
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	// data belongs to the caller, so keep a copy
+	var raw bytes.Buffer
+	if err := json.Compact(&raw, data); err != nil {
+		return err
+	}
+	t.Raw = raw.Bytes()
+	if fields != nil {
+		t.fields = make(map[string]struct{}, len(fields))
+		for name := range fields {
+			t.fields[name] = struct{}{}
+		}
+	}
+
+	if aux.Status != nil {
+		// The status stands in for the completed field
+		if t.fields != nil {
+			t.fields["completed"] = struct{}{}
+		}
+
+		switch *aux.Status {
+		case statusDone:
+			t.Completed = true
+		case statusOpen:
+			t.Completed = false
+		default:
+			return fmt.Errorf("unknown todo status %q", *aux.Status)
+		}
+	}
+
+	return nil
+}
+
+// timestampLayouts are the string formats a timestamp is parsed with, after
+// RFC 3339, which is kept as sent
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	time.RFC1123,
+	time.RFC1123Z,
+}
+
+// epochMillisThreshold separates epoch seconds from epoch milliseconds:
+// seconds stay below it until the year 5138, and milliseconds have been
+// above it since 1973
+const epochMillisThreshold = 1e11
+
+// timestamp decodes a time sent as an RFC 3339 string, Unix epoch seconds
+// or milliseconds (as a number or numeric string), or one of
+// timestampLayouts, and holds it as RFC 3339. Times without a zone are
+// taken as UTC. RFC 3339 strings are kept verbatim so existing state
+// doesn't churn.
+type timestamp string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ts *timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*ts = ""
+		return nil
+	}
+
+	var value string
+	if data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	} else {
+		value = string(data)
+	}
+	if value == "" {
+		*ts = ""
+		return nil
+	}
+
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		*ts = timestamp(value)
+		return nil
+	}
+
+	if epoch, err := strconv.ParseFloat(value, 64); err == nil {
+		var t time.Time
+		if epoch >= epochMillisThreshold || epoch <= -epochMillisThreshold {
+			t = time.UnixMilli(int64(epoch))
+		} else {
+			t = time.Unix(int64(epoch), 0)
+		}
+		*ts = timestamp(t.UTC().Format(time.RFC3339Nano))
+		return nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			*ts = timestamp(t.UTC().Format(time.RFC3339Nano))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unrecognised timestamp %s", data)
+}
+
+// HasField reports whether the API response the todo was decoded from
+// included the JSON field name, so callers can tell a field the server
+// sent empty from one it no longer sends. Todos not decoded from a
+// response report every field as present.
+func (t *Todo) HasField(name string) bool {
+	if t.fields == nil {
+		return true
+	}
+	_, ok := t.fields[name]
+	return ok
+}
+
+// decodeJSON reads a todo-bearing response body into v, see unmarshalJSON
+func (c *Client) decodeJSON(r io.Reader, v interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return c.unmarshalJSON(data, v)
+}
+
+// unmarshalJSON decodes data into v. With LenientDecode, "completed" fields
+// sent as the strings "true"/"false" or "1"/"0", or the numbers 1/0, are
+// first rewritten to booleans.
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if c.LenientDecode {
+		// Malformed JSON is left for json.Unmarshal to report
+		if normalized, err := normalizeCompleted(data); err == nil {
+			data = normalized
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// normalizeCompleted rewrites loosely typed completed flags to booleans in
+// the todos of a JSON document: a todo, a list of todos, a {"todos": [...]}
+// page or a {"results": [{"todo": ...}]} batch response. Keys named
+// completed elsewhere, such as in metadata, are left alone.
+func normalizeCompleted(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	switch v := doc.(type) {
+	case []interface{}:
+		normalizeTodoList(v)
+	case map[string]interface{}:
+		normalizeTodoCompleted(v)
+		if todos, ok := v["todos"].([]interface{}); ok {
+			normalizeTodoList(todos)
+		}
+		if results, ok := v["results"].([]interface{}); ok {
+			for _, result := range results {
+				if result, ok := result.(map[string]interface{}); ok {
+					normalizeTodoCompleted(result["todo"])
+				}
+			}
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// normalizeTodoList normalizes the completed flag of each todo in a list
+func normalizeTodoList(todos []interface{}) {
+	for _, todo := range todos {
+		normalizeTodoCompleted(todo)
+	}
+}
+
+// normalizeTodoCompleted rewrites the completed flag of a decoded todo
+// object in place, if it is loosely typed
+func normalizeTodoCompleted(todo interface{}) {
+	fields, ok := todo.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if completed, ok := looseBool(fields["completed"]); ok {
+		fields["completed"] = completed
+	}
+}
+
+// looseBool interprets the non-boolean spellings of a flag that lenient
+// decoding accepts
+func looseBool(value interface{}) (bool, bool) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = strings.ToLower(strings.TrimSpace(v))
+	case json.Number:
+		s = v.String()
+	default:
+		return false, false
+	}
+
+	switch s {
+	case "true", "1":
+		return true, true
+	case "false", "0":
+		return false, true
+	}
+	return false, false
+}
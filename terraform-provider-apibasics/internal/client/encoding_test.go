@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCompletedEncodingRoundTrip(t *testing.T) {
+	tests := []struct {
+		encoding CompletedEncoding
+		field    string
+		done     interface{}
+		open     interface{}
+	}{
+		{encoding: CompletedEncodingBool, field: "completed", done: true, open: false},
+		{encoding: CompletedEncodingStatus, field: "status", done: statusDone, open: statusOpen},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			for _, completed := range []bool{true, false} {
+				body := map[string]interface{}{}
+				tt.encoding.encode(body, completed)
+
+				want := tt.open
+				if completed {
+					want = tt.done
+				}
+				if len(body) != 1 || body[tt.field] != want {
+					t.Fatalf("encode(%v) = %v, want only %s = %v", completed, body, tt.field, want)
+				}
+
+				data, err := json.Marshal(body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var todo Todo
+				if err := json.Unmarshal(data, &todo); err != nil {
+					t.Fatalf("decoding %s: %v", data, err)
+				}
+				if todo.Completed != completed {
+					t.Errorf("decoded %s as completed = %v, want %v", data, todo.Completed, completed)
+				}
+				if !todo.HasField("completed") {
+					t.Errorf("decoded %s without reporting the completed field", data)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalTodoRejectsUnknownStatus(t *testing.T) {
+	var todo Todo
+	if err := json.Unmarshal([]byte(`{"title":"a","status":"archived"}`), &todo); err == nil {
+		t.Error("decoding an unknown status succeeded, want an error")
+	}
+}
+
+func TestCreateTodoWithStatusEncoding(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		if _, ok := body["completed"]; ok || body["status"] != statusDone {
+			t.Errorf("body = %v, want status %q and no completed field", body, statusDone)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"` + testTodoID + `","title":"Ship it","status":"done"}`))
+	})
+	c := newTestClient(srv)
+	c.CompletedEncoding = CompletedEncodingStatus
+
+	title, completed := "Ship it", true
+	todo, err := c.CreateTodo(context.Background(), TodoInput{Title: &title, Completed: &completed})
+	if err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+	if !todo.Completed {
+		t.Error("completed = false, want true from the done status")
+	}
+}
+
+func TestLenientDecodeOfCompleted(t *testing.T) {
+	lenient := &Client{LenientDecode: true}
+	for body, want := range map[string]bool{
+		`{"completed":"true"}`:    true,
+		`{"completed":" FALSE "}`: false,
+		`{"completed":"1"}`:       true,
+		`{"completed":0}`:         false,
+		`{"completed":true}`:      true,
+	} {
+		var todo Todo
+		if err := lenient.unmarshalJSON([]byte(body), &todo); err != nil {
+			t.Errorf("lenient decode of %s: %v", body, err)
+			continue
+		}
+		if todo.Completed != want {
+			t.Errorf("lenient decode of %s: completed = %v, want %v", body, todo.Completed, want)
+		}
+	}
+
+	var todo Todo
+	if err := (&Client{}).unmarshalJSON([]byte(`{"completed":"true"}`), &todo); err == nil {
+		t.Error("strict decode of a string completed flag succeeded, want an error")
+	}
+}
+
+func TestLenientDecodeOnlyTouchesTodos(t *testing.T) {
+	lenient := &Client{LenientDecode: true}
+
+	var page todoPage
+	body := `{"todos":[{"completed":"1","metadata":{"completed":"1"}},{"completed":"0"}]}`
+	if err := lenient.unmarshalJSON([]byte(body), &page); err != nil {
+		t.Fatalf("decoding page: %v", err)
+	}
+	if len(page.Todos) != 2 || !page.Todos[0].Completed || page.Todos[1].Completed {
+		t.Fatalf("todos = %+v, want completed true then false", page.Todos)
+	}
+	// Metadata is a string map, so its values must stay strings
+	if got := page.Todos[0].Metadata["completed"]; got != "1" {
+		t.Errorf("metadata completed = %q, want %q", got, "1")
+	}
+
+	var list []Todo
+	if err := lenient.unmarshalJSON([]byte(`[{"completed":"true"}]`), &list); err != nil || len(list) != 1 || !list[0].Completed {
+		t.Errorf("decoding list = %+v, %v, want one completed todo", list, err)
+	}
+}
+
+func TestTimestampFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{name: "RFC 3339 kept verbatim", json: `"2024-05-01T14:00:00+02:00"`, want: "2024-05-01T14:00:00+02:00"},
+		{name: "epoch seconds", json: `1714564800`, want: "2024-05-01T12:00:00Z"},
+		{name: "epoch millis", json: `1714564800123`, want: "2024-05-01T12:00:00.123Z"},
+		{name: "epoch seconds as string", json: `"1714564800"`, want: "2024-05-01T12:00:00Z"},
+		{name: "space separated without zone", json: `"2024-05-01 12:00:00"`, want: "2024-05-01T12:00:00Z"},
+		{name: "RFC 1123", json: `"Wed, 01 May 2024 12:00:00 UTC"`, want: "2024-05-01T12:00:00Z"},
+		{name: "null", json: `null`, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var todo Todo
+			data := `{"id":"` + testTodoID + `","createdAt":` + tt.json + `,"updatedAt":` + tt.json + `,"completedAt":` + tt.json + `}`
+			if err := json.Unmarshal([]byte(data), &todo); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			for name, got := range map[string]string{"createdAt": todo.CreatedAt, "updatedAt": todo.UpdatedAt, "completedAt": todo.CompletedAt} {
+				if got != tt.want {
+					t.Errorf("%s = %q, want %q", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTimestampRejectsUnknownFormat(t *testing.T) {
+	var todo Todo
+	if err := json.Unmarshal([]byte(`{"createdAt":"first of May"}`), &todo); err == nil {
+		t.Errorf("Unmarshal() error = nil, want an unrecognised timestamp error")
+	}
+}
+
+func TestGetTodoKeepsRawJSON(t *testing.T) {
+	const body = `{"id":"` + testTodoID + `","title":"Write tests","labels":["urgent"],"estimate":{"hours":2}}`
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{\n  " + body[1:]))
+	})
+
+	todo, err := newTestClient(srv).GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if string(todo.Raw) != body {
+		t.Errorf("Raw = %s, want the compacted response %s", todo.Raw, body)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(todo.Raw, &fields); err != nil {
+		t.Fatalf("decoding Raw: %v", err)
+	}
+	if string(fields["estimate"]) != `{"hours":2}` {
+		t.Errorf("estimate = %s, want the unmodelled field kept", fields["estimate"])
+	}
+}
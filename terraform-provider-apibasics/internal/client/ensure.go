@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// EnsureTodo returns the todo identified by input's title and parent,
+// creating it from input only if none exists. The returned bool reports
+// whether it was created. The API has no lists, so the parent todo stands
+// in as the container: with ParentID nil only top-level todos match.
+//
+// The create is sent with an idempotency key derived from the natural key,
+// so callers racing to ensure the same todo end up with a single one on
+// servers that honour Idempotency-Key.
+func (c *Client) EnsureTodo(ctx context.Context, input TodoInput) (*Todo, bool, error) {
+	if input.Title == nil {
+		return nil, false, errors.New("ensure todo: title is required")
+	}
+	parentID := ""
+	if input.ParentID != nil {
+		parentID = *input.ParentID
+		if err := validateTodoID(parentID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	var found *Todo
+	err := c.ListTodosFunc(ctx, ListOptions{ParentID: parentID}, func(todo Todo) error {
+		if todo.Title != *input.Title || todo.ParentID != parentID {
+			return nil
+		}
+		found = &todo
+		return ErrStopIteration
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("ensure todo: %w", err)
+	}
+	if found != nil {
+		return found, false, nil
+	}
+
+	todo, created, err := c.CreateTodoIdempotent(ctx, naturalKeyIdempotencyKey(*input.Title, parentID), input)
+	if err != nil {
+		return nil, false, err
+	}
+	return todo, created, nil
+}
+
+// naturalKeyIdempotencyKey derives a stable UUID-formatted idempotency key
+// from a todo's title and parent, so every attempt to ensure the same todo
+// sends the same key
+func naturalKeyIdempotencyKey(title, parentID string) string {
+	sum := sha256.Sum256([]byte("ensure\x00" + parentID + "\x00" + title))
+	b := sum[:16]
+
+	// Shaped like a name-based UUID (version 5, RFC 4122 variant)
+	b[6] = (b[6] & 0x0f) | 0x50
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
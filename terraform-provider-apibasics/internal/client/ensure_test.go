@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// ensureServer lists existing and creates testTodoID2, recording the
+// idempotency key of each create
+func ensureServer(t *testing.T, existing []Todo, creates *atomic.Int32, key *string) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, http.StatusOK, existing)
+		case http.MethodPost:
+			creates.Add(1)
+			*key = r.Header.Get(idempotencyKeyHeader)
+			writeJSON(t, w, http.StatusCreated, Todo{ID: testTodoID2, Title: "Bootstrap"})
+		}
+	})
+	return newTestClient(srv)
+}
+
+func TestEnsureTodoFindsExisting(t *testing.T) {
+	var creates atomic.Int32
+	var key string
+	c := ensureServer(t, []Todo{
+		{ID: testTodoID3, Title: "Bootstrap", ParentID: testTodoID},
+		{ID: testTodoID, Title: "Bootstrap"},
+	}, &creates, &key)
+
+	title := "Bootstrap"
+	todo, created, err := c.EnsureTodo(context.Background(), TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("EnsureTodo() error = %v", err)
+	}
+	// The subtask with the same title is not a top-level match
+	if created || todo.ID != testTodoID {
+		t.Errorf("got %s, created = %v, want the existing top-level todo", todo.ID, created)
+	}
+	if creates.Load() != 0 {
+		t.Errorf("creates = %d, want 0", creates.Load())
+	}
+}
+
+func TestEnsureTodoCreatesMissing(t *testing.T) {
+	var creates atomic.Int32
+	var key string
+	c := ensureServer(t, []Todo{{ID: testTodoID, Title: "Something else"}}, &creates, &key)
+
+	title := "Bootstrap"
+	todo, created, err := c.EnsureTodo(context.Background(), TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("EnsureTodo() error = %v", err)
+	}
+	if !created || todo.ID != testTodoID2 {
+		t.Errorf("got %s, created = %v, want a new todo", todo.ID, created)
+	}
+	if want := naturalKeyIdempotencyKey("Bootstrap", ""); key != want {
+		t.Errorf("idempotency key = %q, want the natural key %q", key, want)
+	}
+}
+
+func TestNaturalKeyIdempotencyKey(t *testing.T) {
+	key := naturalKeyIdempotencyKey("Bootstrap", "")
+	if !IsValidUUID(key) {
+		t.Errorf("key %q is not shaped like a UUID", key)
+	}
+	if naturalKeyIdempotencyKey("Bootstrap", "") != key {
+		t.Error("key is not stable for the same natural key")
+	}
+	if naturalKeyIdempotencyKey("Bootstrap", testTodoID) == key {
+		t.Error("todos under different parents share a key")
+	}
+}
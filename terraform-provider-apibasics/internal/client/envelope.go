@@ -0,0 +1,51 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// readErrorEnvelope looks for an "error" field in a successful response body,
+// as sent by backends that report failures with a 2xx status. The body is
+// buffered and replaced so callers can still decode it. An empty message
+// means the response carried no error.
+func readErrorEnvelope(resp *http.Response) (string, []byte, error) {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{bytes.NewReader(bodyBytes), resp.Body}
+
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(bodyBytes, &envelope) != nil || len(envelope.Error) == 0 || string(envelope.Error) == "null" {
+		return "", bodyBytes, nil
+	}
+
+	var message string
+	if json.Unmarshal(envelope.Error, &message) != nil {
+		// Not a string, e.g. an object with a code; report it verbatim
+		message = string(envelope.Error)
+	}
+	return message, bodyBytes, nil
+}
+
+// isRetryableErrorMessage reports whether an error envelope message matches
+// one of RetryableErrorMessages, ignoring case
+func (c *Client) isRetryableErrorMessage(message string) bool {
+	message = strings.ToLower(message)
+	for _, pattern := range c.RetryableErrorMessages {
+		if pattern != "" && strings.Contains(message, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// envelopeServer answers the first failures requests with a 200 carrying
+// an error envelope with message, and later ones with a todo
+func envelopeServer(t *testing.T, failures int32, message string, calls *atomic.Int32) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			writeJSON(t, w, http.StatusOK, map[string]string{"error": message})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Recovered"})
+	})
+	c := newTestClient(srv)
+	c.RetryableErrorMessages = []string{"Temporarily Unavailable"}
+	c.MaxRetries = 2
+	return c
+}
+
+func TestErrorEnvelopeWithRetryableMessageIsRetried(t *testing.T) {
+	var calls atomic.Int32
+	c := envelopeServer(t, 1, "service temporarily unavailable", &calls)
+
+	todo, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if todo.Title != "Recovered" || calls.Load() != 2 {
+		t.Errorf("title = %q after %d calls, want %q after 2", todo.Title, calls.Load(), "Recovered")
+	}
+}
+
+func TestErrorEnvelopeWithOtherMessageFails(t *testing.T) {
+	var calls atomic.Int32
+	c := envelopeServer(t, 1, "quota exceeded", &calls)
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "quota exceeded" {
+		t.Fatalf("GetTodo() error = %v, want an APIError with the envelope message", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1 for a message that isn't retryable", calls.Load())
+	}
+	if apiErr.Operation != "get todo" {
+		t.Errorf("operation = %q, want the caller's operation", apiErr.Operation)
+	}
+}
+
+func TestErrorEnvelopeRetriesAreBounded(t *testing.T) {
+	var calls atomic.Int32
+	c := envelopeServer(t, 100, "temporarily unavailable", &calls)
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK {
+		t.Fatalf("GetTodo() error = %v, want the envelope as an APIError", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want MaxRetries+1 = 3", calls.Load())
+	}
+}
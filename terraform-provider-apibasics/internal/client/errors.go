@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// APIError is returned when the API responds with an unexpected status code
+type APIError struct {
+	// Operation describes the failed call, e.g. "create todo"
+	Operation  string
+	StatusCode int
+
+	// Body is the raw response body
+	Body string
+
+	// Message is the "message" field of a JSON error body, if present
+	Message string
+
+	// RequestID is the correlation id of the failed request, as echoed by
+	// the server or else as sent, for quoting to the API's operators
+	RequestID string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s failed (status %d, request id %s): %s", e.Operation, e.StatusCode, e.RequestID, e.Body)
+	}
+	return fmt.Sprintf("%s failed (status %d): %s", e.Operation, e.StatusCode, e.Body)
+}
+
+// newAPIError builds an APIError from an unexpected response, consuming its body
+func newAPIError(operation string, resp *http.Response) *APIError {
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		Body:       string(bodyBytes),
+		RequestID:  responseRequestID(resp),
+	}
+
+	var envelope struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(bodyBytes, &envelope) == nil {
+		apiErr.Message = envelope.Message
+	}
+
+	return apiErr
+}
+
+// IsForbidden reports whether err is an APIError for a 403 response: the
+// token was valid but lacks permission, so logging in again will not help
+func IsForbidden(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
+}
+
+// IsTransient reports whether err looks like a passing outage rather than a
+// problem with the request: a 5xx response, or a network failure before any
+// response arrived. A 404 is not transient, nor is a cancelled request.
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
@@ -0,0 +1,40 @@
+package client
+
+import "strings"
+
+// entityTag is a parsed ETag header value
+type entityTag struct {
+	Weak   bool
+	Opaque string
+}
+
+// parseETag splits an ETag such as `W/"abc"` or `"abc"` into its weakness
+// flag and opaque value. An empty string yields the zero entityTag.
+func parseETag(raw string) entityTag {
+	raw = strings.TrimSpace(raw)
+	tag := entityTag{}
+	if strings.HasPrefix(raw, "W/") {
+		tag.Weak = true
+		raw = raw[2:]
+	}
+	tag.Opaque = strings.Trim(raw, `"`)
+	return tag
+}
+
+// etagWeakMatch implements RFC 7232 weak comparison, used to check that a
+// 304 Not Modified revalidated the cached copy: two tags match if their
+// opaque values are equal, regardless of whether either is weak.
+func etagWeakMatch(a, b string) bool {
+	ta, tb := parseETag(a), parseETag(b)
+	return ta.Opaque != "" && ta.Opaque == tb.Opaque
+}
+
+// ifMatchValue returns the header value to send in If-Match for a stored
+// ETag. Weak tags can never satisfy If-Match, so none is sent for them.
+func ifMatchValue(etag string) string {
+	tag := parseETag(etag)
+	if tag.Weak || tag.Opaque == "" {
+		return ""
+	}
+	return `"` + tag.Opaque + `"`
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestETagWeakMatch(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`"abc"`, `"abc"`, true},
+		{`W/"abc"`, `"abc"`, true},
+		{`W/"abc"`, `W/"abc"`, true},
+		{`"abc"`, `"abd"`, false},
+		{`""`, `""`, false},
+		{``, `"abc"`, false},
+	}
+	for _, tt := range tests {
+		if got := etagWeakMatch(tt.a, tt.b); got != tt.want {
+			t.Errorf("etagWeakMatch(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIfMatchValue(t *testing.T) {
+	tests := map[string]string{
+		`"abc"`:   `"abc"`,
+		`abc`:     `"abc"`,
+		`W/"abc"`: ``,
+		``:        ``,
+	}
+	for etag, want := range tests {
+		if got := ifMatchValue(etag); got != want {
+			t.Errorf("ifMatchValue(%q) = %q, want %q", etag, got, want)
+		}
+	}
+}
+
+// revalidationServer serves testTodoID with ETag `"v1"`, then answers
+// conditional requests with a 304 carrying notModifiedETag
+func revalidationServer(t *testing.T, notModifiedETag string, requests *int) *Client {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("If-None-Match") != "" {
+			w.Header().Set("ETag", notModifiedETag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v2"`)
+		writeJSON(t, w, http.StatusOK, map[string]any{"id": testTodoID, "title": "fetched"})
+	})
+	c := newTestClient(srv)
+	c.EnableReadCache(time.Nanosecond)
+	c.readCache.put(&Todo{ID: testTodoID, Title: "cached"}, `"v1"`)
+	return c
+}
+
+func TestGetTodoRevalidatesWithWeakETag(t *testing.T) {
+	requests := 0
+	c := revalidationServer(t, `W/"v1"`, &requests)
+
+	todo, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todo.Title != "cached" || requests != 1 {
+		t.Errorf("got title %q after %d requests, want the cached todo after 1", todo.Title, requests)
+	}
+}
+
+func TestGetTodoRefetchesWhenNotModifiedNamesAnotherETag(t *testing.T) {
+	requests := 0
+	c := revalidationServer(t, `"other"`, &requests)
+
+	todo, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todo.Title != "fetched" || requests != 2 {
+		t.Errorf("got title %q after %d requests, want the fetched todo after 2", todo.Title, requests)
+	}
+}
+
+// conditionalDeleteServer deletes its todo only while its ETag is current,
+// recording the If-Match header of each delete
+func conditionalDeleteServer(t *testing.T, current string, ifMatch *string) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		*ifMatch = r.Header.Get("If-Match")
+		if *ifMatch != "" && *ifMatch != current {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return newTestClient(srv)
+}
+
+func TestDeleteTodoIfMatch(t *testing.T) {
+	var ifMatch string
+	c := conditionalDeleteServer(t, `"v2"`, &ifMatch)
+
+	if err := c.DeleteTodoIfMatch(context.Background(), testTodoID, `"v1"`); !errors.Is(err, ErrTodoChanged) {
+		t.Errorf("stale delete error = %v, want ErrTodoChanged", err)
+	}
+	if err := c.DeleteTodoIfMatch(context.Background(), testTodoID, `"v2"`); err != nil {
+		t.Errorf("current delete error = %v", err)
+	}
+	if ifMatch != `"v2"` {
+		t.Errorf("If-Match = %q, want %q", ifMatch, `"v2"`)
+	}
+}
+
+func TestDeleteTodoIfMatchWithWeakETagIsUnconditional(t *testing.T) {
+	var ifMatch string
+	c := conditionalDeleteServer(t, `"v2"`, &ifMatch)
+
+	// If-Match needs a strong comparison, which a weak ETag can't give
+	if err := c.DeleteTodoIfMatch(context.Background(), testTodoID, `W/"v1"`); err != nil {
+		t.Fatalf("DeleteTodoIfMatch() error = %v", err)
+	}
+	if ifMatch != "" {
+		t.Errorf("If-Match = %q, want none for a weak ETag", ifMatch)
+	}
+}
+
+// versionedServer holds one todo at version current and rejects updates
+// based on any other version with 409 Conflict
+func versionedServer(t *testing.T, current int) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Title   string `json:"title"`
+			Version *int   `json:"version"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding update: %v", err)
+		}
+		if body.Version == nil {
+			t.Errorf("update sent no version")
+		} else if *body.Version != current {
+			writeJSON(t, w, http.StatusConflict, map[string]string{"message": "version mismatch"})
+			return
+		}
+		// Older servers call the version a generation
+		writeJSON(t, w, http.StatusOK, map[string]any{"id": testTodoID, "title": body.Title, "generation": current + 1})
+	})
+	return newTestClient(srv)
+}
+
+func TestUpdateTodoWithVersion(t *testing.T) {
+	title := "Renamed"
+
+	version := 4
+	todo, err := versionedServer(t, 4).UpdateTodo(context.Background(), testTodoID, TodoInput{Title: &title, Version: &version})
+	if err != nil {
+		t.Fatalf("UpdateTodo() with the current version error = %v", err)
+	}
+	if todo.Version != 5 {
+		t.Errorf("Version = %d, want 5 from the generation field", todo.Version)
+	}
+
+	stale := 3
+	_, err = versionedServer(t, 4).UpdateTodo(context.Background(), testTodoID, TodoInput{Title: &title, Version: &stale})
+	if !errors.Is(err, ErrTodoChanged) {
+		t.Errorf("UpdateTodo() with a stale version error = %v, want ErrTodoChanged", err)
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats supported by ExportTodo
+const (
+	ExportFormatJSON = "json"
+	ExportFormatYAML = "yaml"
+)
+
+// todoExport is the serialised form of a todo produced by ExportTodo
+type todoExport struct {
+	ID          string            `json:"id,omitempty" yaml:"id,omitempty"`
+	UserID      string            `json:"userId,omitempty" yaml:"userId,omitempty"`
+	Title       string            `json:"title" yaml:"title"`
+	Description string            `json:"description" yaml:"description"`
+	Completed   bool              `json:"completed" yaml:"completed"`
+	ParentID    string            `json:"parentId,omitempty" yaml:"parentId,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	CreatedAt   string            `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	UpdatedAt   string            `json:"updatedAt,omitempty" yaml:"updatedAt,omitempty"`
+}
+
+// newTodoExport copies the exported fields of todo
+func newTodoExport(todo Todo) todoExport {
+	return todoExport{
+		ID:          todo.ID,
+		UserID:      todo.UserID,
+		Title:       todo.Title,
+		Description: todo.Description,
+		Completed:   todo.Completed,
+		ParentID:    todo.ParentID,
+		Metadata:    todo.Metadata,
+		CreatedAt:   todo.CreatedAt,
+		UpdatedAt:   todo.UpdatedAt,
+	}
+}
+
+// ExportTodo fetches a todo and serialises it as JSON or YAML. When
+// stripServerFields is set, the id, owner and timestamps are left out so the
+// result can be used to recreate the todo elsewhere.
+func (c *Client) ExportTodo(ctx context.Context, id, format string, stripServerFields bool) ([]byte, error) {
+	if format != ExportFormatJSON && format != ExportFormatYAML {
+		return nil, fmt.Errorf("unsupported export format %q: must be %q or %q", format, ExportFormatJSON, ExportFormatYAML)
+	}
+
+	todo, err := c.GetTodo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	export := newTodoExport(*todo)
+	if stripServerFields {
+		export.ID = ""
+		export.UserID = ""
+		export.CreatedAt = ""
+		export.UpdatedAt = ""
+	}
+
+	if format == ExportFormatYAML {
+		data, err := yaml.Marshal(export)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode todo as YAML: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode todo as JSON: %w", err)
+	}
+	return data, nil
+}
+
+// ExportAllTodos streams every todo to w as newline-delimited JSON, one
+// todo per line, writing each page as it arrives rather than holding the
+// whole list in memory. It returns the number of todos written. A failed
+// write stops the export and is returned; w may then hold a partial export.
+func (c *Client) ExportAllTodos(ctx context.Context, w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	count := 0
+	err := c.ListTodosFunc(ctx, ListOptions{}, func(todo Todo) error {
+		if err := encoder.Encode(newTodoExport(todo)); err != nil {
+			return fmt.Errorf("failed to write todo %s: %w", todo.ID, err)
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
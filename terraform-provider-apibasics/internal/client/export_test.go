@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pagedServer serves todos in pages of one, linked by cursor
+func pagedServer(t *testing.T, todos []Todo) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// An absent cursor parses as the first page
+		i, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		page := todoPage{Todos: todos[i : i+1]}
+		if i+1 < len(todos) {
+			page.NextCursor = strconv.Itoa(i + 1)
+		}
+		writeJSON(t, w, http.StatusOK, page)
+	})
+	return newTestClient(srv)
+}
+
+func TestExportAllTodosWritesNDJSON(t *testing.T) {
+	c := pagedServer(t, []Todo{
+		{ID: testTodoID, Title: "First", Completed: true},
+		{ID: testTodoID2, Title: "Second", Metadata: map[string]string{"team": "core"}},
+	})
+
+	var buf bytes.Buffer
+	count, err := c.ExportAllTodos(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("ExportAllTodos() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("export = %q, want 2 lines", buf.String())
+	}
+	var second todoExport
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("decoding line 2: %v", err)
+	}
+	if second.ID != testTodoID2 || second.Metadata["team"] != "core" {
+		t.Errorf("line 2 = %+v, want the second todo", second)
+	}
+}
+
+// failingWriter accepts n writes and then fails
+type failingWriter struct {
+	n int
+}
+
+var errDiskFull = errors.New("disk full")
+
+// Write implements io.Writer
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n == 0 {
+		return 0, errDiskFull
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestExportAllTodosStopsOnWriteError(t *testing.T) {
+	c := pagedServer(t, []Todo{{ID: testTodoID}, {ID: testTodoID2}, {ID: testTodoID3}})
+
+	count, err := c.ExportAllTodos(context.Background(), &failingWriter{n: 1})
+	if !errors.Is(err, errDiskFull) {
+		t.Fatalf("ExportAllTodos() error = %v, want the write error", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1 todo written before the failure", count)
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HealthStatusOK is reported when the API answers a health probe with 2xx
+	// and does not name a status of its own
+	HealthStatusOK = "ok"
+
+	// HealthStatusUnhealthy is reported for any other answer without a status
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// Health is the result of a health probe
+type Health struct {
+	// Status is the status the API reported, or HealthStatusOK or
+	// HealthStatusUnhealthy based on the response code
+	Status string
+
+	// StatusCode is the HTTP status of the probe
+	StatusCode int
+
+	// Latency is how long the probe took
+	Latency time.Duration
+}
+
+// Healthy reports whether the probe got a 2xx response
+func (h *Health) Healthy() bool {
+	return h.StatusCode >= 200 && h.StatusCode < 300
+}
+
+// Ping probes GET /health, falling back to the API root for servers without
+// a health endpoint. Each probe is a single attempt so that Latency reflects
+// one round trip; an error means the API could not be reached at all.
+func (c *Client) Ping(ctx context.Context) (*Health, error) {
+	health, err := c.probe(ctx, "/health")
+	if err != nil {
+		return nil, err
+	}
+	if health.StatusCode == http.StatusNotFound {
+		return c.probe(ctx, "/")
+	}
+	return health, nil
+}
+
+// probe sends one GET to path and reads the status from the response
+func (c *Client) probe(ctx context.Context, path string) (*Health, error) {
+	opts := RequestOptions{Headers: http.Header{"Accept": []string{"application/json"}}}
+
+	start := time.Now()
+	_, resp, err := c.send(ctx, "GET", path, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	health := &Health{StatusCode: resp.StatusCode}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	bodyBytes, err := io.ReadAll(resp.Body)
+	health.Latency = time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if json.Unmarshal(bodyBytes, &body) == nil && body.Status != "" {
+		health.Status = strings.ToLower(body.Status)
+	}
+
+	switch {
+	case health.Status != "":
+		// Reported by the API
+	case health.Healthy():
+		health.Status = HealthStatusOK
+	default:
+		health.Status = HealthStatusUnhealthy
+	}
+
+	return health, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Todo ids used by the tests; the client rejects ids that aren't UUIDs
+const (
+	testTodoID  = "0b5a7c9e-1f2d-4e3a-8b6c-7d9e0f1a2b3c"
+	testTodoID2 = "1c6b8d0f-2e3f-4a5b-9c7d-8e0f1a2b3c4d"
+	testTodoID3 = "4f9e1a3c-5b6c-4d8e-8f0a-1b3c4d5e6f7a"
+	testUserID  = "2d7c9e1a-3f4a-4b6c-8d8e-9f1a2b3c4d5e"
+)
+
+// newTestServer starts an httptest server that is closed when the test ends
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newTestClient returns a client for srv that already holds an access
+// token and retries with millisecond backoff
+func newTestClient(srv *httptest.Server) *Client {
+	c := NewClient(srv.URL, "", "")
+	c.AccessToken = "test-token"
+	c.RetryBaseDelay = time.Millisecond
+	c.RetryMaxDelay = 5 * time.Millisecond
+	return c
+}
+
+// writeJSON writes v as a JSON response with the given status
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+// tokenIssuer answers login and refresh requests with a new numbered
+// access token each time, and counts them
+type tokenIssuer struct {
+	mu        sync.Mutex
+	logins    int
+	refreshes int
+	issued    int
+}
+
+// serveToken writes a token response for a login or refresh request
+func (ti *tokenIssuer) serveToken(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	t.Helper()
+	ti.mu.Lock()
+	if r.URL.Path == DefaultRefreshPath {
+		ti.refreshes++
+	} else {
+		ti.logins++
+	}
+	ti.issued++
+	n := ti.issued
+	ti.mu.Unlock()
+
+	writeJSON(t, w, http.StatusOK, TokenResponse{
+		TokenType:    "Bearer",
+		AccessToken:  fmt.Sprintf("token-%d", n),
+		RefreshToken: fmt.Sprintf("refresh-%d", n),
+	})
+}
+
+// counts returns the number of logins and refreshes served so far
+func (ti *tokenIssuer) counts() (logins, refreshes int) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	return ti.logins, ti.refreshes
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrHistoryUnsupported is returned by GetTodoHistory when the API has no
+// history endpoint
+var ErrHistoryUnsupported = errors.New("the API does not support todo history")
+
+// TodoRevision is one recorded change to a todo
+type TodoRevision struct {
+	// Revision numbers changes in order, when the server reports it
+	Revision int `json:"revision,omitempty"`
+
+	Timestamp string `json:"timestamp"`
+
+	// ModifiedBy is the id of the user who made the change, if tracked
+	ModifiedBy string `json:"modifiedBy,omitempty"`
+
+	// ChangedFields names the todo fields the change touched
+	ChangedFields []string `json:"changedFields"`
+}
+
+// GetTodoHistory returns the recorded changes to a todo from
+// GET /todos/{id}/history, oldest first as the server orders them. The
+// response may be a bare array or an object with a "revisions" field.
+func (c *Client) GetTodoHistory(ctx context.Context, id string) ([]TodoRevision, error) {
+	if err := validateTodoID(id); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.DoRequestWithOptions(ctx, "GET", "/todos/"+id+"/history", nil, RequestOptions{Operation: "get todo history"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		// Tell a missing todo apart from a missing endpoint
+		if _, err := c.GetTodo(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrHistoryUnsupported
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("get todo history", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	revisions := []TodoRevision{}
+	if trimmed := bytes.TrimSpace(respBody); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &revisions); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return revisions, nil
+	}
+
+	var body struct {
+		Revisions []TodoRevision `json:"revisions"`
+	}
+	if err := json.Unmarshal(respBody, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if body.Revisions != nil {
+		revisions = body.Revisions
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// idempotencyKeyHeader carries the key that lets the server recognise a
+// retried create
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a random UUIDv4 to use as an idempotency key
+func NewIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
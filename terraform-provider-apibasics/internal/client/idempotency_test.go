@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestCreateTodoIdempotentDetectsReplayedCreate(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		attempt := len(keys)
+		mu.Unlock()
+
+		// The first attempt creates the todo but its response is lost
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Once"})
+	})
+	c := newTestClient(srv)
+
+	key, err := NewIdempotencyKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	title := "Once"
+	todo, created, err := c.CreateTodoIdempotent(context.Background(), key, TodoInput{Title: &title})
+	if err != nil {
+		t.Fatalf("CreateTodoIdempotent() error = %v", err)
+	}
+	if created {
+		t.Error("created = true, want false for a replayed create")
+	}
+	if todo.ID != testTodoID {
+		t.Errorf("id = %q, want the existing todo %q", todo.ID, testTodoID)
+	}
+	if len(keys) != 2 || keys[0] != key || keys[1] != key {
+		t.Errorf("idempotency keys sent = %v, want %q on both attempts", keys, key)
+	}
+}
+
+func TestCreateTodoIdempotentStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		echo        bool
+		wantCreated bool
+	}{
+		{name: "created", status: http.StatusCreated, wantCreated: true},
+		{name: "existing", status: http.StatusOK},
+		{name: "key echoed", status: http.StatusCreated, echo: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.echo {
+					w.Header().Set(idempotencyKeyHeader, r.Header.Get(idempotencyKeyHeader))
+				}
+				writeJSON(t, w, tt.status, Todo{ID: testTodoID, Title: "Once"})
+			})
+			c := newTestClient(srv)
+
+			title := "Once"
+			_, created, err := c.CreateTodoIdempotent(context.Background(), "key-1", TodoInput{Title: &title})
+			if err != nil {
+				t.Fatalf("CreateTodoIdempotent() error = %v", err)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("created = %v, want %v", created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestNewIdempotencyKeyIsUUID(t *testing.T) {
+	key, err := NewIdempotencyKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsValidUUID(key) {
+		t.Errorf("NewIdempotencyKey() = %q, want a UUID", key)
+	}
+}
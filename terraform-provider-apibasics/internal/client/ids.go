@@ -0,0 +1,53 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidID is wrapped by the error returned for a malformed todo id,
+// which is rejected before any request is sent
+var ErrInvalidID = errors.New("invalid todo id format")
+
+// IsValidUUID reports whether s looks like a UUID: 32 hex digits, either
+// grouped 8-4-4-4-12 with hyphens or ungrouped. Any version and variant, and
+// either case, is accepted so that no id the server issues is rejected.
+func IsValidUUID(s string) bool {
+	switch len(s) {
+	case 36:
+		for i := 0; i < len(s); i++ {
+			switch i {
+			case 8, 13, 18, 23:
+				if s[i] != '-' {
+					return false
+				}
+			default:
+				if !isHexDigit(s[i]) {
+					return false
+				}
+			}
+		}
+		return true
+	case 32:
+		for i := 0; i < len(s); i++ {
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isHexDigit reports whether b is 0-9, a-f or A-F
+func isHexDigit(b byte) bool {
+	return ('0' <= b && b <= '9') || ('a' <= b && b <= 'f') || ('A' <= b && b <= 'F')
+}
+
+// validateTodoID returns an error wrapping ErrInvalidID unless id is a UUID
+func validateTodoID(id string) error {
+	if !IsValidUUID(id) {
+		return fmt.Errorf("%w %q: expected a UUID", ErrInvalidID, id)
+	}
+	return nil
+}
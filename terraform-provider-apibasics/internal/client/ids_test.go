@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestIsValidUUID(t *testing.T) {
+	valid := []string{
+		testTodoID,
+		"0B5A7C9E-1F2D-4E3A-8B6C-7D9E0F1A2B3C",
+		"0b5a7c9e1f2d4e3a8b6c7d9e0f1a2b3c",
+		"00000000-0000-0000-0000-000000000000",
+	}
+	for _, id := range valid {
+		if !IsValidUUID(id) {
+			t.Errorf("IsValidUUID(%q) = false, want true", id)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"42",
+		"0b5a7c9e-1f2d-4e3a-8b6c-7d9e0f1a2b3",
+		"0b5a7c9e_1f2d_4e3a_8b6c_7d9e0f1a2b3c",
+		"0b5a7c9e-1f2d-4e3a-8b6c-7d9e0f1a2b3g",
+		"../todos/0b5a7c9e-1f2d-4e3a-8b6c-7d9e",
+	}
+	for _, id := range invalid {
+		if IsValidUUID(id) {
+			t.Errorf("IsValidUUID(%q) = true, want false", id)
+		}
+	}
+}
+
+func TestMalformedIDsAreRejectedBeforeSending(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent for a malformed id: %s %s", r.Method, r.URL.Path)
+	})
+	c := newTestClient(srv)
+	ctx := context.Background()
+	title := "x"
+
+	calls := map[string]func(id string) error{
+		"GetTodo": func(id string) error {
+			_, err := c.GetTodo(ctx, id)
+			return err
+		},
+		"UpdateTodo": func(id string) error {
+			_, err := c.UpdateTodo(ctx, id, TodoInput{Title: &title})
+			return err
+		},
+		"DeleteTodo": func(id string) error {
+			return c.DeleteTodo(ctx, id)
+		},
+	}
+	for name, call := range calls {
+		if err := call("not-a-uuid"); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("%s() error = %v, want ErrInvalidID", name, err)
+		}
+	}
+}
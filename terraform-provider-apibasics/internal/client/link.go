@@ -0,0 +1,55 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+)
+
+// linkTarget returns the URL of the first link with relation rel in the
+// response's Link headers (RFC 8288, formerly RFC 5988), or an empty string
+func linkTarget(header http.Header, rel string) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range splitLinks(value) {
+			target, params, ok := strings.Cut(link, ";")
+			target = strings.TrimSpace(target)
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(param, "=")
+				if !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				// rel may hold several space-separated relation types
+				for _, relType := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if strings.EqualFold(relType, rel) {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// splitLinks splits a Link header value into its comma-separated links,
+// ignoring commas inside the <...> URLs
+func splitLinks(value string) []string {
+	var links []string
+	inURL, start := false, 0
+	for i, r := range value {
+		switch r {
+		case '<':
+			inURL = true
+		case '>':
+			inURL = false
+		case ',':
+			if !inURL {
+				links = append(links, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(links, value[start:])
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLinkTarget(t *testing.T) {
+	tests := []struct {
+		name  string
+		links []string
+		want  string
+	}{
+		{name: "single", links: []string{`</todos?page=2>; rel="next"`}, want: "/todos?page=2"},
+		{name: "several links", links: []string{`</todos?page=1>; rel="prev", </todos?page=3>; rel="next"`}, want: "/todos?page=3"},
+		{name: "several headers", links: []string{`</todos?page=1>; rel="first"`, `</todos?page=2>; rel=next`}, want: "/todos?page=2"},
+		{name: "comma in url", links: []string{`</todos?ids=a,b>; rel="next"`}, want: "/todos?ids=a,b"},
+		{name: "several relations", links: []string{`</todos?page=2>; rel="next last"`}, want: "/todos?page=2"},
+		{name: "case insensitive", links: []string{`</todos?page=2>; REL="Next"`}, want: "/todos?page=2"},
+		{name: "no next", links: []string{`</todos?page=1>; rel="prev"`}, want: ""},
+		{name: "malformed", links: []string{`/todos?page=2; rel="next"`}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{"Link": tt.links}
+			if got := linkTarget(header, "next"); got != tt.want {
+				t.Errorf("linkTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListTodosFollowsLinkHeaders(t *testing.T) {
+	var srvURL string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			// Absolute links to the API are followed like relative ones
+			w.Header().Set("Link", `<`+srvURL+`/todos?page=2>; rel="next"`)
+			writeJSON(t, w, http.StatusOK, []Todo{{ID: testTodoID}})
+		case "2":
+			w.Header().Set("Link", `</todos?page=3>; rel="next"`)
+			writeJSON(t, w, http.StatusOK, []Todo{{ID: testTodoID2}})
+		case "3":
+			writeJSON(t, w, http.StatusOK, []Todo{{ID: testTodoID3}})
+		}
+	})
+	srvURL = srv.URL
+	c := newTestClient(srv)
+
+	todos, err := c.ListTodos(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+	if got, want := todoIDs(todos), []string{testTodoID, testTodoID2, testTodoID3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,476 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrStopIteration can be returned from a ListTodosFunc callback to stop
+// paging early without reporting an error
+var ErrStopIteration = errors.New("stop iteration")
+
+// ListOptions controls pagination of the todo list endpoint
+type ListOptions struct {
+	// PageSize is the number of todos to request per page, sent as limit.
+	// Servers may return fewer; see warnPageSizeClamped.
+	PageSize int
+	Cursor   string
+
+	// ParentID restricts the list to direct children of a todo
+	ParentID string
+
+	// ModifiedBy restricts the list to todos last changed by a user
+	ModifiedBy string
+
+	// SortBy orders the list by each field in turn, so later fields break
+	// ties in earlier ones. The server's default order is used when empty.
+	SortBy []SortField
+
+	// CreatedAfter and CreatedBefore restrict the list to todos created
+	// strictly between them. Either may be left zero for an open range.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Query is a search query sent as q, see QueryTodos
+	Query string
+
+	// AllowPartial makes ListTodos and ListTodosFunc stop paging when the
+	// context deadline is about to pass, or has passed, once at least one
+	// page has arrived. The todos gathered so far are then returned along
+	// with an error wrapping ErrPartialList instead of failing outright.
+	// ListTodosParallel likewise returns the pages that arrived in time.
+	AllowPartial bool
+}
+
+// ErrPartialList is wrapped by the error a list with AllowPartial returns
+// when it ran out of time before reaching the last page
+var ErrPartialList = errors.New("the list is incomplete")
+
+// validate reports options the list endpoint cannot honour
+func (o ListOptions) validate() error {
+	if !o.CreatedAfter.IsZero() && !o.CreatedBefore.IsZero() && !o.CreatedAfter.Before(o.CreatedBefore) {
+		return fmt.Errorf("created after %s must be earlier than created before %s",
+			o.CreatedAfter.Format(time.RFC3339), o.CreatedBefore.Format(time.RFC3339))
+	}
+	if o.Query != "" {
+		if _, err := ParseQuery(o.Query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matches applies the created range locally, for servers that ignore the
+// query parameters. Todos whose creation time cannot be parsed are kept.
+func (o ListOptions) matches(todo Todo) bool {
+	if o.CreatedAfter.IsZero() && o.CreatedBefore.IsZero() {
+		return true
+	}
+	createdAt, err := time.Parse(time.RFC3339, todo.CreatedAt)
+	if err != nil {
+		return true
+	}
+	if !o.CreatedAfter.IsZero() && !createdAt.After(o.CreatedAfter) {
+		return false
+	}
+	if !o.CreatedBefore.IsZero() && !createdAt.Before(o.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// todoPage represents a single page of the todo list response
+type todoPage struct {
+	Todos      []Todo `json:"todos"`
+	NextCursor string `json:"nextCursor"`
+
+	// Total is the number of todos across all pages, when the server reports it
+	Total int `json:"total"`
+
+	// NextPath is the rel="next" target of the Link header, relative to
+	// BaseURL, for servers that paginate with Link headers instead of cursors
+	NextPath string `json:"-"`
+}
+
+// ListTodos retrieves all todos, following pagination cursors or Link headers
+func (c *Client) ListTodos(ctx context.Context, opts ListOptions) ([]Todo, error) {
+	todos := []Todo{}
+	err := c.ListTodosFunc(ctx, opts, func(todo Todo) error {
+		todos = append(todos, todo)
+		return nil
+	})
+	if errors.Is(err, ErrPartialList) {
+		return todos, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// ListTodosFunc walks every page of todos and calls fn for each one.
+// Todos repeated across overlapping pages are only passed to fn once.
+// Returning ErrStopIteration from fn stops paging early.
+func (c *Client) ListTodosFunc(ctx context.Context, opts ListOptions, fn func(Todo) error) error {
+	seen := make(map[string]struct{})
+	duplicates := 0
+	defer func() {
+		if duplicates > 0 {
+			tflog.Warn(ctx, "List pages returned duplicate todos; the server's pagination cursor may be unstable", map[string]any{
+				"duplicates": duplicates,
+			})
+		}
+	}()
+
+	// Pages are followed by body cursor or by Link header, whichever the
+	// server sends
+	cursor, nextPath := opts.Cursor, ""
+	clampWarned := false
+	pages := 0
+	var lastPageTime time.Duration
+	for {
+		// Stop early if the next page is unlikely to arrive in time,
+		// guessing it takes as long as the last one
+		if deadline, ok := ctx.Deadline(); ok && opts.AllowPartial && pages > 0 && time.Until(deadline) < lastPageTime {
+			return fmt.Errorf("%w: stopped after %d pages as the deadline neared", ErrPartialList, pages)
+		}
+
+		pageStart := time.Now()
+		var page *todoPage
+		var err error
+		if nextPath != "" {
+			page, err = c.fetchTodoPage(ctx, nextPath)
+		} else {
+			page, err = c.listTodosPage(ctx, opts, cursor, 0)
+		}
+		// Only the caller's deadline makes the list partial; a single attempt
+		// timing out under PerRequestTimeout is an ordinary failure
+		if err != nil && opts.AllowPartial && pages > 0 && ctx.Err() != nil {
+			return fmt.Errorf("%w: stopped after %d pages: %w", ErrPartialList, pages, err)
+		}
+		if err != nil {
+			return err
+		}
+		lastPageTime = time.Since(pageStart)
+		pages++
+
+		// Only a page followed by another shows the server capped its size
+		hasNext := page.NextCursor != "" || page.NextPath != ""
+		if !clampWarned && hasNext && opts.PageSize > len(page.Todos) {
+			warnPageSizeClamped(ctx, opts.PageSize, len(page.Todos))
+			clampWarned = true
+		}
+
+		for _, todo := range page.Todos {
+			if _, ok := seen[todo.ID]; ok {
+				duplicates++
+				continue
+			}
+			seen[todo.ID] = struct{}{}
+			if !opts.matches(todo) {
+				continue
+			}
+
+			if err := fn(todo); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		switch {
+		case page.NextCursor != "" && page.NextCursor != cursor:
+			cursor, nextPath = page.NextCursor, ""
+		case page.NextPath != "" && page.NextPath != nextPath:
+			nextPath = page.NextPath
+		default:
+			return nil
+		}
+	}
+}
+
+// listTodosPage fetches a single page of todos, addressed either by cursor
+// or, when pageNumber is positive, by 1-based page number. The API may
+// respond with either a bare array (unpaginated) or a page envelope.
+func (c *Client) listTodosPage(ctx context.Context, opts ListOptions, cursor string, pageNumber int) (*todoPage, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if opts.PageSize > 0 {
+		query.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if pageNumber > 0 {
+		query.Set("page", strconv.Itoa(pageNumber))
+	}
+	if opts.ParentID != "" {
+		query.Set("parentId", opts.ParentID)
+	}
+	if opts.ModifiedBy != "" {
+		query.Set("modifiedBy", opts.ModifiedBy)
+	}
+	if len(opts.SortBy) > 0 {
+		sortBy, err := encodeSort(opts.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("sort", sortBy)
+	}
+	if !opts.CreatedAfter.IsZero() {
+		query.Set("createdAfter", opts.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if !opts.CreatedBefore.IsZero() {
+		query.Set("createdBefore", opts.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+	if opts.Query != "" {
+		query.Set("q", opts.Query)
+	}
+
+	path := "/todos"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	page, err := c.fetchTodoPage(ctx, path)
+	if err != nil && opts.Query != "" {
+		return nil, queryRejected(err)
+	}
+	return page, err
+}
+
+// fetchTodoPage fetches the page of todos at path, fetching it once more if
+// the body arrives truncated
+func (c *Client) fetchTodoPage(ctx context.Context, path string) (*todoPage, error) {
+	var page *todoPage
+	err := c.refetchOnDecodeError(ctx, path, func() (err error) {
+		page, err = c.fetchTodoPageOnce(ctx, path)
+		return err
+	})
+	return page, err
+}
+
+// fetchTodoPageOnce makes a single attempt to fetch a page of todos
+func (c *Client) fetchTodoPageOnce(ctx context.Context, path string) (*todoPage, error) {
+	resp, err := c.DoRequestWithOptions(ctx, "GET", path, nil, RequestOptions{Operation: "list todos"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, newAPIError("list todos", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &decodeError{err: err}
+	}
+
+	var page todoPage
+	if next := linkTarget(resp.Header, "next"); next != "" {
+		page.NextPath, err = c.relativePath(next)
+		if err != nil {
+			return nil, fmt.Errorf("invalid next page link: %w", err)
+		}
+	}
+
+	// Some servers answer an empty list with no body at all
+	trimmed := bytes.TrimSpace(respBody)
+	if len(trimmed) == 0 {
+		return &page, nil
+	}
+
+	if trimmed[0] == '[' {
+		if err := c.unmarshalJSON(trimmed, &page.Todos); err != nil {
+			return nil, &decodeError{err: err}
+		}
+		return &page, nil
+	}
+
+	if err := c.unmarshalJSON(respBody, &page); err != nil {
+		return nil, &decodeError{err: err}
+	}
+
+	return &page, nil
+}
+
+// ListTodosParallel retrieves all todos like ListTodos, but when the server
+// reports the total count on the first page the remaining pages are fetched
+// concurrently by page number. Results keep the server's order and are
+// deduplicated by id. If the total is unknown it falls back to sequential
+// cursor pagination.
+func (c *Client) ListTodosParallel(ctx context.Context, opts ListOptions) ([]Todo, error) {
+	firstStart := time.Now()
+	first, err := c.listTodosPage(ctx, opts, opts.Cursor, 0)
+	if err != nil {
+		return nil, err
+	}
+	firstPageTime := time.Since(firstStart)
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = len(first.Todos)
+	}
+	if pageSize > len(first.Todos) && first.Total > len(first.Todos) {
+		// Page numbers must be computed from the size the server uses
+		warnPageSizeClamped(ctx, pageSize, len(first.Todos))
+		pageSize = len(first.Todos)
+	}
+	if first.Total <= len(first.Todos) || pageSize == 0 {
+		if first.NextCursor == "" && first.NextPath == "" {
+			return filterTodos(dedupTodos(ctx, first.Todos), opts), nil
+		}
+		tflog.Debug(ctx, "Server did not report a total todo count, listing pages sequentially")
+		return c.ListTodos(ctx, opts)
+	}
+
+	pageCount := (first.Total + pageSize - 1) / pageSize
+	pages := make([][]Todo, pageCount)
+	pages[0] = first.Todos
+
+	// Stop early if the other pages are unlikely to arrive in time,
+	// guessing they take as long as the first one
+	if deadline, ok := ctx.Deadline(); ok && opts.AllowPartial && time.Until(deadline) < firstPageTime {
+		return filterTodos(dedupTodos(ctx, first.Todos), opts),
+			fmt.Errorf("%w: stopped after 1 of %d pages as the deadline neared", ErrPartialList, pageCount)
+	}
+
+	pageOpts := opts
+	pageOpts.PageSize = pageSize
+	errs := runBulk(ctx, pageCount-1, func(ctx context.Context, i int) error {
+		page, err := c.listTodosPage(ctx, pageOpts, "", i+2)
+		if err != nil {
+			return err
+		}
+		pages[i+1] = page.Todos
+		return nil
+	})
+	fetched, firstErr := 1, error(nil)
+	for _, err := range errs {
+		if err == nil {
+			fetched++
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	// As with ListTodosFunc, only the caller's deadline makes the list partial
+	if firstErr != nil && !(opts.AllowPartial && ctx.Err() != nil) {
+		return nil, firstErr
+	}
+
+	var todos []Todo
+	for _, page := range pages {
+		todos = append(todos, page...)
+	}
+
+	todos = filterTodos(dedupTodos(ctx, todos), opts)
+	if firstErr != nil {
+		return todos, fmt.Errorf("%w: fetched %d of %d pages: %w", ErrPartialList, fetched, pageCount, firstErr)
+	}
+	return todos, nil
+}
+
+// warnPageSizeClamped logs that the server returned smaller pages than
+// requested, most likely because it caps the page size
+func warnPageSizeClamped(ctx context.Context, requested, returned int) {
+	tflog.Warn(ctx, "Server returned fewer todos per page than requested, it probably caps the page size; paging continues at the smaller size", map[string]any{
+		"requested_page_size": requested,
+		"returned_page_size":  returned,
+	})
+}
+
+// dedupTodos removes repeated todos, keeping the first occurrence of each id
+func dedupTodos(ctx context.Context, todos []Todo) []Todo {
+	seen := make(map[string]struct{}, len(todos))
+	result := make([]Todo, 0, len(todos))
+	for _, todo := range todos {
+		if _, ok := seen[todo.ID]; ok {
+			continue
+		}
+		seen[todo.ID] = struct{}{}
+		result = append(result, todo)
+	}
+
+	if duplicates := len(todos) - len(result); duplicates > 0 {
+		tflog.Warn(ctx, "List pages returned duplicate todos; the server's pagination may be unstable", map[string]any{
+			"duplicates": duplicates,
+		})
+	}
+
+	return result
+}
+
+// filterTodos keeps the todos that match opts
+func filterTodos(todos []Todo, opts ListOptions) []Todo {
+	result := todos[:0]
+	for _, todo := range todos {
+		if opts.matches(todo) {
+			result = append(result, todo)
+		}
+	}
+	return result
+}
+
+// ListTodoChildren returns the direct children of the todo with parentID
+func (c *Client) ListTodoChildren(ctx context.Context, parentID string) ([]Todo, error) {
+	children := []Todo{}
+	err := c.ListTodosFunc(ctx, ListOptions{ParentID: parentID}, func(todo Todo) error {
+		// Guard against servers that ignore the parentId filter
+		if todo.ParentID == parentID {
+			children = append(children, todo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// ErrModifiedByUnsupported is returned by ListTodosByModifier when the API
+// does not record who modified todos, so no answer can be given
+var ErrModifiedByUnsupported = errors.New("the API does not report which user modified todos")
+
+// ListTodosByModifier returns the todos last modified by the user with
+// userID. The API is asked to filter with ?modifiedBy=, and results are
+// filtered again locally for servers that ignore the parameter. Other
+// options such as SortBy are passed through.
+func (c *Client) ListTodosByModifier(ctx context.Context, userID string, opts ListOptions) ([]Todo, error) {
+	matches := []Todo{}
+	seen, tracked := 0, false
+	opts.ModifiedBy = userID
+	err := c.ListTodosFunc(ctx, opts, func(todo Todo) error {
+		seen++
+		if todo.ModifiedBy != "" {
+			tracked = true
+		}
+		if todo.ModifiedBy == userID {
+			matches = append(matches, todo)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrPartialList) {
+		return nil, err
+	}
+
+	// Todos came back but none carry the field: an older backend
+	if seen > 0 && !tracked {
+		return nil, ErrModifiedByUnsupported
+	}
+	return matches, err
+}
@@ -0,0 +1,306 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// todoIDs returns the ids of todos in order
+func todoIDs(todos []Todo) []string {
+	ids := make([]string, 0, len(todos))
+	for _, todo := range todos {
+		ids = append(ids, todo.ID)
+	}
+	return ids
+}
+
+func TestListTodosDedupsOverlappingPages(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			writeJSON(t, w, http.StatusOK, todoPage{Todos: []Todo{{ID: testTodoID}, {ID: testTodoID2}}, NextCursor: "page-2"})
+		case "page-2":
+			// The cursor is unstable and repeats the last todo of page 1
+			writeJSON(t, w, http.StatusOK, todoPage{Todos: []Todo{{ID: testTodoID2}, {ID: testTodoID3}}})
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	})
+	c := newTestClient(srv)
+
+	todos, err := c.ListTodos(context.Background(), ListOptions{})
+	if err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+	if got, want := todoIDs(todos), []string{testTodoID, testTodoID2, testTodoID3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+// numberedTodos returns n todos whose ids are valid UUIDs ending in their
+// position
+func numberedTodos(n int) []Todo {
+	todos := make([]Todo, n)
+	for i := range todos {
+		todos[i] = Todo{ID: fmt.Sprintf("00000000-0000-4000-8000-%012d", i+1), Title: strconv.Itoa(i + 1)}
+	}
+	return todos
+}
+
+func TestListTodosParallelKeepsOrder(t *testing.T) {
+	all := numberedTodos(7)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		// Earlier pages answer last, so arrival order differs from page order
+		time.Sleep(time.Duration(5-page) * 5 * time.Millisecond)
+
+		start := (page - 1) * 2
+		end := start + 2
+		if end > len(all) {
+			end = len(all)
+		}
+		todos := all[start:end]
+		if page == 3 {
+			// An overlap with page 2 must be dropped
+			todos = append([]Todo{all[3]}, todos...)
+		}
+		writeJSON(t, w, http.StatusOK, todoPage{Todos: todos, Total: len(all), NextCursor: "unused"})
+	})
+	c := newTestClient(srv)
+
+	todos, err := c.ListTodosParallel(context.Background(), ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListTodosParallel() error = %v", err)
+	}
+	if got, want := todoIDs(todos), todoIDs(all); !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func TestListTodosParallelFallsBackWithoutTotal(t *testing.T) {
+	all := numberedTodos(3)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "" {
+			t.Errorf("requested page %s without a known total", r.URL.Query().Get("page"))
+		}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			writeJSON(t, w, http.StatusOK, todoPage{Todos: all[:2], NextCursor: "next"})
+		default:
+			writeJSON(t, w, http.StatusOK, todoPage{Todos: all[2:]})
+		}
+	})
+	c := newTestClient(srv)
+
+	todos, err := c.ListTodosParallel(context.Background(), ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListTodosParallel() error = %v", err)
+	}
+	if got, want := todoIDs(todos), todoIDs(all); !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func TestListTodosCreatedRange(t *testing.T) {
+	after := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("createdAfter") != "2024-05-01T00:00:00Z" || query.Get("createdBefore") != "2024-06-01T00:00:00Z" {
+			t.Errorf("query = %v, want the range in RFC 3339", query)
+		}
+		// The server ignores the range, so the client filters locally
+		writeJSON(t, w, http.StatusOK, []Todo{
+			{ID: testTodoID, CreatedAt: "2024-04-30T23:59:59Z"},
+			{ID: testTodoID2, CreatedAt: "2024-05-15T12:00:00Z"},
+			{ID: testTodoID3, CreatedAt: "2024-06-01T00:00:00Z"},
+		})
+	})
+	c := newTestClient(srv)
+
+	todos, err := c.ListTodos(context.Background(), ListOptions{CreatedAfter: after, CreatedBefore: before})
+	if err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+	if got, want := todoIDs(todos), []string{testTodoID2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+func TestListTodosRejectsInvertedRange(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for an invalid range")
+	})
+	c := newTestClient(srv)
+
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	_, err := c.ListTodos(context.Background(), ListOptions{CreatedAfter: at.Add(time.Hour), CreatedBefore: at})
+	if err == nil || !strings.Contains(err.Error(), "must be earlier than") {
+		t.Errorf("ListTodos() error = %v, want a range validation error", err)
+	}
+}
+
+// clampingServer serves all as pages of at most two todos, whatever
+// limit asks for, addressed by cursor or page number
+func clampingServer(t *testing.T, all []Todo, limits *[]string) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		mu.Lock()
+		*limits = append(*limits, query.Get("limit"))
+		mu.Unlock()
+
+		start, _ := strconv.Atoi(query.Get("cursor"))
+		if page, err := strconv.Atoi(query.Get("page")); err == nil {
+			start = (page - 1) * 2
+		}
+		end := min(start+2, len(all))
+		page := todoPage{Todos: all[start:end], Total: len(all)}
+		if end < len(all) {
+			page.NextCursor = strconv.Itoa(end)
+		}
+		writeJSON(t, w, http.StatusOK, page)
+	})
+	return newTestClient(srv)
+}
+
+func TestListTodosWithClampedPageSize(t *testing.T) {
+	all := numberedTodos(5)
+	var limits []string
+	c := clampingServer(t, all, &limits)
+
+	todos, err := c.ListTodos(context.Background(), ListOptions{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+	if got, want := todoIDs(todos), todoIDs(all); !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+	if want := []string{"10", "10", "10"}; !reflect.DeepEqual(limits, want) {
+		t.Errorf("limits = %v, want %v", limits, want)
+	}
+}
+
+func TestListTodosParallelWithClampedPageSize(t *testing.T) {
+	all := numberedTodos(5)
+	var limits []string
+	c := clampingServer(t, all, &limits)
+
+	// Page numbers must follow the server's page size, not the requested one
+	todos, err := c.ListTodosParallel(context.Background(), ListOptions{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListTodosParallel() error = %v", err)
+	}
+	if got, want := todoIDs(todos), todoIDs(all); !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %v, want %v", got, want)
+	}
+}
+
+// stallingPagesServer serves one todo per page, and stalls every page from
+// stallFrom on until the request is abandoned
+func stallingPagesServer(t *testing.T, todos []Todo, stallFrom int) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		i, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		if i >= stallFrom {
+			<-r.Context().Done()
+			return
+		}
+		page := todoPage{Todos: todos[i : i+1]}
+		if i+1 < len(todos) {
+			page.NextCursor = strconv.Itoa(i + 1)
+		}
+		writeJSON(t, w, http.StatusOK, page)
+	})
+	c := newTestClient(srv)
+	c.MaxRetries = 0
+	return c
+}
+
+func TestListTodosAllowPartialReturnsPagesSoFar(t *testing.T) {
+	todos := numberedTodos(5)
+	c := stallingPagesServer(t, todos, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	got, err := c.ListTodos(ctx, ListOptions{AllowPartial: true})
+	if !errors.Is(err, ErrPartialList) {
+		t.Fatalf("ListTodos() error = %v, want ErrPartialList", err)
+	}
+	if ids := todoIDs(got); len(ids) != 2 || ids[0] != todos[0].ID || ids[1] != todos[1].ID {
+		t.Errorf("todos = %v, want the first two pages", ids)
+	}
+}
+
+func TestListTodosWithoutAllowPartialFailsAtDeadline(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      ListOptions
+		stallFrom int
+	}{
+		{name: "not allowed", stallFrom: 2},
+		{name: "no page arrived", opts: ListOptions{AllowPartial: true}, stallFrom: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := stallingPagesServer(t, numberedTodos(5), tt.stallFrom)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			got, err := c.ListTodos(ctx, tt.opts)
+			if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrPartialList) {
+				t.Errorf("ListTodos() error = %v, want the deadline and no partial list", err)
+			}
+			if got != nil {
+				t.Errorf("todos = %v, want none", todoIDs(got))
+			}
+		})
+	}
+}
+
+func TestListTodosParallelAllowPartialReturnsPagesInTime(t *testing.T) {
+	all := numberedTodos(6)
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		if page == 3 {
+			<-r.Context().Done()
+			return
+		}
+		start := (page - 1) * 2
+		writeJSON(t, w, http.StatusOK, todoPage{Todos: all[start : start+2], Total: len(all)})
+	})
+	c := newTestClient(srv)
+	c.MaxRetries = 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	got, err := c.ListTodosParallel(ctx, ListOptions{PageSize: 2, AllowPartial: true})
+	if !errors.Is(err, ErrPartialList) {
+		t.Fatalf("ListTodosParallel() error = %v, want ErrPartialList", err)
+	}
+	if ids, want := todoIDs(got), todoIDs(all[:4]); !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want the first two pages %v", ids, want)
+	}
+
+	// Without AllowPartial the deadline fails the list
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if got, err := c.ListTodosParallel(ctx, ListOptions{PageSize: 2}); got != nil || errors.Is(err, ErrPartialList) {
+		t.Errorf("ListTodosParallel() = %v, %v, want no partial list", todoIDs(got), err)
+	}
+}
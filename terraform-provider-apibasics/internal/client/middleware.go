@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RoundTripFunc sends a request and returns its response
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to add behaviour around each API request,
+// such as setting headers, logging or collecting metrics. A middleware calls
+// next to continue the chain, and may modify the request before and the
+// response after.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// handler builds the chain every authenticated request passes through: the
+// built-in middleware first, then Client.Middleware in order, then the HTTP
+// client. User middleware therefore sees the request exactly as it will be
+// sent, including the Authorization header.
+func (c *Client) handler() RoundTripFunc {
+	chain := []Middleware{
+		c.slowRequestMiddleware,
+		c.authMiddleware,
+		c.apiKeyMiddleware,
+		c.priorityMiddleware,
+		c.userAgentMiddleware,
+		c.signingMiddleware,
+		c.requestIDMiddleware,
+		c.loggingMiddleware,
+	}
+	chain = append(chain, c.Middleware...)
+
+	next := RoundTripFunc(c.HTTPClient.Do)
+	for i := len(chain) - 1; i >= 0; i-- {
+		next = chain[i](next)
+	}
+	return next
+}
+
+// authMiddleware sends the current access token, from Credentials if set,
+// if there is one
+func (c *Client) authMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		token := c.accessToken()
+		if c.Credentials != nil {
+			var err error
+			if token, err = c.Credentials.Token(req.Context()); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrCredentialProvider, err)
+			}
+		}
+		recordSentToken(req.Context(), token)
+
+		if token != "" {
+			req.Header.Set("Authorization", c.authorization(token))
+		}
+		return next(req)
+	}
+}
+
+// priorityMiddleware sends RequestPriority as the Priority header
+func (c *Client) priorityMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.RequestPriority != "" {
+			req.Header.Set("Priority", c.RequestPriority)
+		}
+		return next(req)
+	}
+}
+
+// userAgentMiddleware identifies the client to the API
+func (c *Client) userAgentMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		return next(req)
+	}
+}
+
+// slowRequestMiddleware logs a warning for requests slower than
+// SlowRequestThreshold
+func (c *Client) slowRequestMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+		if elapsed := time.Since(start); c.SlowRequestThreshold > 0 && elapsed > c.SlowRequestThreshold {
+			tflog.Warn(req.Context(), "Slow API request", map[string]any{
+				"method":      req.Method,
+				"path":        req.URL.Path,
+				"duration_ms": elapsed.Milliseconds(),
+				"threshold":   c.SlowRequestThreshold.String(),
+			})
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// preferReturnMinimal asks the server to answer writes without a body
+// (RFC 7240)
+const preferReturnMinimal = "return=minimal"
+
+// writeOptions adds the Prefer header to opts when PreferMinimal is set
+func (c *Client) writeOptions(opts RequestOptions) RequestOptions {
+	if !c.PreferMinimal {
+		return opts
+	}
+
+	headers := opts.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("Prefer", preferReturnMinimal)
+	opts.Headers = headers
+	return opts
+}
+
+// isMinimalResponse reports whether a successful write came back without
+// the todo in its body
+func isMinimalResponse(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusNoContent || resp.ContentLength == 0
+}
+
+// fetchWrittenTodo reads back a todo whose write was answered without a
+// body. id is the todo that was updated, or empty for a create, in which
+// case it is taken from the Location header. The read cache is bypassed,
+// since it may still hold the todo from before the write.
+func (c *Client) fetchWrittenTodo(ctx context.Context, operation, id string, resp *http.Response) (*Todo, error) {
+	if id == "" {
+		location := resp.Header.Get("Location")
+		if location == "" {
+			return nil, fmt.Errorf("%s returned no body and no Location header", operation)
+		}
+		todoPath, err := c.relativePath(location)
+		if err != nil {
+			return nil, err
+		}
+		todoPath, _, _ = strings.Cut(todoPath, "?")
+		id = path.Base(todoPath)
+		if err := validateTodoID(id); err != nil {
+			return nil, fmt.Errorf("%s returned Location %q: %w", operation, location, err)
+		}
+	}
+
+	tflog.Debug(ctx, "Reading back todo after minimal response", map[string]any{"operation": operation, "id": id})
+	var todo *Todo
+	err := c.refetchOnDecodeError(ctx, "/todos/"+id, func() (err error) {
+		todo, err = c.fetchTodo(ctx, "/todos/"+id, RequestOptions{})
+		return err
+	})
+	return todo, err
+}
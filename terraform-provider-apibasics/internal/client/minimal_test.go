@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// minimalServer answers writes without a body when asked to, and serves the
+// written todo on a follow-up GET. It records each request as "METHOD path".
+type minimalServer struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (s *minimalServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mu.Unlock()
+
+		minimal := r.Header.Get("Prefer") == preferReturnMinimal
+		switch {
+		case r.Method == http.MethodGet:
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Write tests", CreatedAt: "2024-05-01T10:00:00Z"})
+		case r.Method == http.MethodPost && minimal:
+			w.Header().Set("Location", "/todos/"+testTodoID)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && minimal:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Write tests", CreatedAt: "2024-05-01T10:00:00Z"})
+		}
+	}
+}
+
+func TestPreferMinimalReadsBackWrites(t *testing.T) {
+	title := "Write tests"
+	tests := []struct {
+		name  string
+		write func(c *Client) (*Todo, error)
+		want  []string
+	}{
+		{
+			name:  "create answered with Location",
+			write: func(c *Client) (*Todo, error) { return c.CreateTodo(context.Background(), TodoInput{Title: &title}) },
+			want:  []string{"POST /todos", "GET /todos/" + testTodoID},
+		},
+		{
+			name: "update answered with 204",
+			write: func(c *Client) (*Todo, error) {
+				return c.UpdateTodo(context.Background(), testTodoID, TodoInput{Title: &title})
+			},
+			want: []string{"PUT /todos/" + testTodoID, "GET /todos/" + testTodoID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s minimalServer
+			c := newTestClient(newTestServer(t, s.handler(t)))
+			c.PreferMinimal = true
+
+			todo, err := tt.write(c)
+			if err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			if todo.ID != testTodoID || todo.CreatedAt == "" {
+				t.Errorf("todo = %+v, want the full todo from the follow-up GET", todo)
+			}
+			if got, want := strings.Join(s.requests, ", "), strings.Join(tt.want, ", "); got != want {
+				t.Errorf("requests = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestWithoutPreferMinimalUsesResponseBody(t *testing.T) {
+	var s minimalServer
+	c := newTestClient(newTestServer(t, s.handler(t)))
+
+	title := "Write tests"
+	if _, err := c.UpdateTodo(context.Background(), testTodoID, TodoInput{Title: &title}); err != nil {
+		t.Fatalf("UpdateTodo() error = %v", err)
+	}
+	if len(s.requests) != 1 {
+		t.Errorf("requests = %v, want only the update", s.requests)
+	}
+}
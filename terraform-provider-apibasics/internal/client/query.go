@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidQuery is wrapped by the error ParseQuery returns for a query
+// with malformed syntax
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ErrQueryRejected is wrapped by the error a list with a Query returns when
+// the API refuses a well-formed query, e.g. because it names an unknown field
+var ErrQueryRejected = errors.New("the API rejected the query")
+
+// QueryTerm is one whitespace-separated term of a search query
+type QueryTerm struct {
+	// Field is the part before the colon in field:value, or empty for a
+	// free-text term
+	Field string
+	Value string
+
+	// Negated is set by a leading "-", which excludes matching todos
+	Negated bool
+}
+
+// ParseQuery checks the syntax of a search query such as
+// `completed:false priority:high -tag:later "release notes"`, returning its
+// terms. A term is field:value or bare free text; either part may be
+// double-quoted to include spaces, with \" for a literal quote. Field names
+// start with a letter or underscore and may contain letters, digits,
+// underscores and dots. Which fields exist is up to the server.
+func ParseQuery(query string) ([]QueryTerm, error) {
+	var terms []QueryTerm
+	rest := strings.TrimSpace(query)
+	if rest == "" {
+		return nil, fmt.Errorf("%w: query is empty", ErrInvalidQuery)
+	}
+
+	for rest != "" {
+		var term QueryTerm
+		if rest[0] == '-' {
+			term.Negated = true
+			rest = rest[1:]
+		}
+
+		first, remainder, quoted, err := scanQueryWord(rest)
+		if err != nil {
+			return nil, err
+		}
+		rest = remainder
+
+		if !quoted && strings.HasPrefix(rest, ":") {
+			term.Field = first
+			if !isQueryField(term.Field) {
+				return nil, fmt.Errorf("%w: invalid field name %q", ErrInvalidQuery, term.Field)
+			}
+			if rest = rest[1:]; rest == "" || rest[0] == ' ' || rest[0] == '\t' {
+				return nil, fmt.Errorf("%w: field %q has no value", ErrInvalidQuery, term.Field)
+			}
+			if term.Value, rest, _, err = scanQueryWord(rest); err != nil {
+				return nil, err
+			}
+		} else {
+			term.Value = first
+		}
+
+		if term.Value == "" && !quoted {
+			return nil, fmt.Errorf("%w: empty term", ErrInvalidQuery)
+		}
+		if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+			return nil, fmt.Errorf("%w: unexpected %q after %q", ErrInvalidQuery, rest[:1], term.Value)
+		}
+
+		terms = append(terms, term)
+		rest = strings.TrimLeft(rest, " \t")
+	}
+
+	return terms, nil
+}
+
+// scanQueryWord reads a bare word, which ends at whitespace or a colon, or
+// a double-quoted string from the start of s, returning it unquoted along
+// with the rest of s
+func scanQueryWord(s string) (word, rest string, quoted bool, err error) {
+	if !strings.HasPrefix(s, `"`) {
+		end := strings.IndexAny(s, " \t:\"")
+		if end < 0 {
+			return s, "", false, nil
+		}
+		return s[:end], s[end:], false, nil
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\') {
+				i++
+			}
+			b.WriteByte(s[i])
+		case '"':
+			return b.String(), s[i+1:], true, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", true, fmt.Errorf("%w: unterminated quote in %s", ErrInvalidQuery, s)
+}
+
+// isQueryField reports whether name is a valid query field name
+func isQueryField(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && ('0' <= r && r <= '9' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// QueryTodos returns the todos matching a search query, sent to the list
+// endpoint as q. The syntax is checked locally first, see ParseQuery; the
+// server decides which fields can be searched and reports a query it
+// refuses as ErrQueryRejected. APIs without search ignore the parameter and
+// return every todo.
+func (c *Client) QueryTodos(ctx context.Context, query string) ([]Todo, error) {
+	return c.ListTodos(ctx, ListOptions{Query: query})
+}
+
+// queryRejected converts the API's refusal of a search query into an error
+// wrapping ErrQueryRejected, passing other errors through
+func queryRejected(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusUnprocessableEntity) {
+		return err
+	}
+
+	reason := apiErr.Message
+	if reason == "" {
+		reason = apiErr.Body
+	}
+	return fmt.Errorf("%w: %s", ErrQueryRejected, reason)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []QueryTerm
+	}{
+		{query: "completed:false", want: []QueryTerm{{Field: "completed", Value: "false"}}},
+		{
+			query: `completed:false  priority:high -tag:later "release notes"`,
+			want: []QueryTerm{
+				{Field: "completed", Value: "false"},
+				{Field: "priority", Value: "high"},
+				{Field: "tag", Value: "later", Negated: true},
+				{Value: "release notes"},
+			},
+		},
+		{query: `title:"say \"hi\""`, want: []QueryTerm{{Field: "title", Value: `say "hi"`}}},
+		{query: "metadata.team:core", want: []QueryTerm{{Field: "metadata.team", Value: "core"}}},
+		{query: `""`, want: []QueryTerm{{Value: ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryRejectsMalformedSyntax(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"   ",
+		"completed:",
+		"completed: false",
+		"1st:value",
+		`title:"unterminated`,
+		`tag:a"b`,
+		"-",
+	} {
+		if _, err := ParseQuery(query); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("ParseQuery(%q) error = %v, want ErrInvalidQuery", query, err)
+		}
+	}
+}
+
+func TestQueryTodos(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "priority:high" {
+			writeJSON(t, w, http.StatusBadRequest, map[string]string{"message": "unknown field"})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, todoPage{Todos: []Todo{{ID: testTodoID}}})
+	})
+	c := newTestClient(srv)
+
+	todos, err := c.QueryTodos(context.Background(), "priority:high")
+	if err != nil {
+		t.Fatalf("QueryTodos() error = %v", err)
+	}
+	if len(todos) != 1 || todos[0].ID != testTodoID {
+		t.Errorf("todos = %+v, want the matching todo", todos)
+	}
+
+	_, err = c.QueryTodos(context.Background(), "colour:red")
+	if !errors.Is(err, ErrQueryRejected) {
+		t.Errorf("QueryTodos() of an unsupported field error = %v, want ErrQueryRejected", err)
+	}
+}
+
+func TestQueryTodosChecksSyntaxBeforeSending(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for query %q", r.URL.Query().Get("q"))
+	})
+
+	if _, err := newTestClient(srv).QueryTodos(context.Background(), "completed:"); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("QueryTodos() error = %v, want ErrInvalidQuery", err)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultSensitiveFields are always redacted from logged bodies
+var DefaultSensitiveFields = []string{"password", "token", "access_token", "refresh_token"}
+
+// redactedValue replaces sensitive values in logs
+const redactedValue = "***"
+
+// redactBody renders a JSON body for logging with the values of sensitive
+// keys, at any depth, replaced. Keys are compared case-insensitively.
+// Bodies that aren't JSON are summarised rather than logged, since they
+// can't be inspected for sensitive content.
+func (c *Client) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON content>", len(body))
+	}
+
+	sensitive := make(map[string]bool, len(DefaultSensitiveFields)+len(c.SensitiveFields))
+	for _, field := range DefaultSensitiveFields {
+		sensitive[strings.ToLower(field)] = true
+	}
+	for _, field := range c.SensitiveFields {
+		sensitive[strings.ToLower(field)] = true
+	}
+
+	redacted, err := json.Marshal(redactValue(value, sensitive))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of unloggable content>", len(body))
+	}
+	return string(redacted)
+}
+
+// redactValue masks sensitive keys in a decoded JSON value
+func redactValue(value interface{}, sensitive map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if sensitive[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(inner, sensitive)
+			}
+		}
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = redactValue(inner, sensitive)
+		}
+	}
+	return value
+}
+
+// maxLoggedBodySize caps how much of a response body is buffered for trace
+// logging. Larger bodies are streamed to the caller without being logged.
+const maxLoggedBodySize = 64 << 10
+
+// traceLoggingEnabled reports whether provider logs are written at trace
+// level, the only level bodies are logged at. Terraform sets the level
+// through TF_LOG_PROVIDER, or TF_LOG for every component.
+func traceLoggingEnabled() bool {
+	level := os.Getenv("TF_LOG_PROVIDER")
+	if level == "" {
+		level = os.Getenv("TF_LOG")
+	}
+	switch strings.ToUpper(level) {
+	case "TRACE", "JSON":
+		return true
+	}
+	return false
+}
+
+// loggingMiddleware logs request and response bodies at trace level, with
+// sensitive fields redacted. Headers are never logged. Bodies are only
+// read when trace logging is enabled.
+func (c *Client) loggingMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if !traceLoggingEnabled() {
+			return next(req)
+		}
+		ctx := req.Context()
+
+		fields := map[string]any{"method": req.Method, "path": req.URL.Path}
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				bodyBytes, _ := io.ReadAll(body)
+				body.Close()
+				fields["body"] = c.redactBody(bodyBytes)
+			}
+		}
+		tflog.Trace(ctx, "Sending API request", fields)
+
+		resp, err := next(req)
+		if err != nil {
+			return resp, err
+		}
+
+		// Buffer the start of the body so it can be logged and still read
+		// by the caller
+		bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodySize+1))
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(bodyBytes), resp.Body), resp.Body}
+
+		logged := fmt.Sprintf("<more than %d bytes, not logged>", maxLoggedBodySize)
+		if len(bodyBytes) <= maxLoggedBodySize {
+			logged = c.redactBody(bodyBytes)
+		}
+		tflog.Trace(ctx, "Received API response", map[string]any{
+			"method": req.Method,
+			"path":   req.URL.Path,
+			"status": resp.StatusCode,
+			"body":   logged,
+		})
+		return resp, nil
+	}
+}
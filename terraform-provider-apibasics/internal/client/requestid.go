@@ -0,0 +1,76 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestIDHeader carries the correlation id of a request, which servers
+// typically echo back and record in their own logs
+const requestIDHeader = "X-Request-ID"
+
+// LastRequestID returns the correlation id of the most recent request that
+// got a response: the id the server echoed, or else the one that was sent.
+// Empty until a request completes or when SendRequestIDs is off and the
+// server assigns none.
+func (c *Client) LastRequestID() string {
+	c.requestIDMu.Lock()
+	defer c.requestIDMu.Unlock()
+
+	return c.lastRequestID
+}
+
+// requestIDMiddleware sends a fresh X-Request-ID with each attempt when
+// SendRequestIDs is set, logs it, and records the id the server answered
+// with for LastRequestID
+func (c *Client) requestIDMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.SendRequestIDs && req.Header.Get(requestIDHeader) == "" {
+			// Without randomness the request is still worth sending, so
+			// it just goes out without an id
+			if id, err := NewIdempotencyKey(); err == nil {
+				req.Header.Set(requestIDHeader, id)
+			}
+		}
+		sent := req.Header.Get(requestIDHeader)
+
+		resp, err := next(req)
+		if err != nil {
+			if sent != "" {
+				tflog.Debug(req.Context(), "API request failed", map[string]any{
+					"method":     req.Method,
+					"path":       req.URL.Path,
+					"request_id": sent,
+				})
+			}
+			return resp, err
+		}
+
+		if id := responseRequestID(resp); id != "" {
+			c.requestIDMu.Lock()
+			c.lastRequestID = id
+			c.requestIDMu.Unlock()
+
+			tflog.Debug(req.Context(), "API request", map[string]any{
+				"method":     req.Method,
+				"path":       req.URL.Path,
+				"status":     resp.StatusCode,
+				"request_id": id,
+			})
+		}
+		return resp, nil
+	}
+}
+
+// responseRequestID returns the correlation id the server echoed in resp,
+// or else the one its request was sent with
+func responseRequestID(resp *http.Response) string {
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		return id
+	}
+	if resp.Request != nil {
+		return resp.Request.Header.Get(requestIDHeader)
+	}
+	return ""
+}
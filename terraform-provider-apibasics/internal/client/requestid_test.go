@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestSendRequestIDsSendsAndLogsID(t *testing.T) {
+	var sent []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get(requestIDHeader))
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+	})
+	c := newTestClient(srv)
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetTodo(ctx, testTodoID); err != nil {
+			t.Fatalf("GetTodo() error = %v", err)
+		}
+	}
+
+	if len(sent) != 2 || !IsValidUUID(sent[0]) || sent[0] == sent[1] {
+		t.Fatalf("sent request ids %q, want a fresh UUID per request", sent)
+	}
+	if got := c.LastRequestID(); got != sent[1] {
+		t.Errorf("LastRequestID() = %q, want %q", got, sent[1])
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatalf("decoding logs: %v", err)
+	}
+	logged := map[string]bool{}
+	for _, entry := range entries {
+		if id, ok := entry["request_id"].(string); ok {
+			logged[id] = true
+		}
+	}
+	for _, id := range sent {
+		if !logged[id] {
+			t.Errorf("request id %s not logged", id)
+		}
+	}
+}
+
+func TestLastRequestIDPrefersServerEcho(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(requestIDHeader); id != "" {
+			t.Errorf("sent request id %q with SendRequestIDs off", id)
+		}
+		w.Header().Set(requestIDHeader, "server-assigned")
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID})
+	})
+	c := newTestClient(srv)
+	c.SendRequestIDs = false
+
+	if _, err := c.GetTodo(context.Background(), testTodoID); err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if got := c.LastRequestID(); got != "server-assigned" {
+		t.Errorf("LastRequestID() = %q, want the server's id", got)
+	}
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxRetries is the number of retries used when none is configured
+const DefaultMaxRetries = 3
+
+// Backoff bounds used when the client doesn't set its own
+const (
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// Idempotency declares whether a request is safe to send more than once
+type Idempotency int
+
+const (
+	// IdempotencyFromMethod treats GET, HEAD, OPTIONS, PUT and DELETE as
+	// idempotent and everything else (POST, PATCH) as not
+	IdempotencyFromMethod Idempotency = iota
+
+	// Idempotent marks a request as safe to retry regardless of its method,
+	// e.g. a PATCH that sends every field
+	Idempotent
+
+	// NotIdempotent marks a request as unsafe to retry regardless of its
+	// method, e.g. a PUT whose effect depends on the current server state
+	NotIdempotent
+)
+
+// RequestOptions controls how DoRequestWithOptions sends a request
+type RequestOptions struct {
+	Idempotency Idempotency
+
+	// Headers are added to the request before authentication headers
+	Headers http.Header
+
+	// Operation names the request in the errors it returns, such as
+	// "get todo". Empty uses the method and path.
+	Operation string
+}
+
+// operation returns the name of a request with the given method and path
+// for its errors
+func (o RequestOptions) operation(method, path string) string {
+	if o.Operation != "" {
+		return o.Operation
+	}
+	return method + " " + path
+}
+
+// allowsRetry reports whether a request with the given method may be retried
+func (i Idempotency) allowsRetry(method string) bool {
+	switch i {
+	case Idempotent:
+		return true
+	case NotIdempotent:
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// DefaultShouldRetry retries transport errors such as refused connections,
+// 429 Too Many Requests and transient 5xx responses. Errors from the
+// CredentialProvider are not retried.
+func DefaultShouldRetry(_ *http.Request, resp *http.Response, err error, _ int) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCredentialProvider)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || isRetryableStatus(resp.StatusCode)
+}
+
+// isTemporaryDNSError reports whether err is a DNS lookup failure that is
+// likely to clear up, such as a resolver timeout while a container's network
+// is still starting. The request never reached the server, so it is safe to
+// retry whatever its method.
+func isTemporaryDNSError(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && (dnsErr.IsTemporary || dnsErr.IsTimeout)
+}
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decodeError wraps a failure to read or decode the body of an otherwise
+// successful response
+type decodeError struct {
+	err error
+}
+
+// Error implements the error interface
+func (e *decodeError) Error() string {
+	return "failed to decode response: " + e.err.Error()
+}
+
+// Unwrap returns the underlying read or decode error
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// refetchOnDecodeError runs fetch, which must send an idempotent GET and
+// decode its response, a second time if the body could not be decoded. A
+// connection dropped mid-body looks just like malformed JSON, and fetching
+// an unchanged resource again is harmless. A body that fails to decode
+// twice is genuinely malformed, so the second error is returned. Nothing
+// is refetched when retries are disabled or the wait would exceed
+// RetryBudget.
+func (c *Client) refetchOnDecodeError(ctx context.Context, path string, fetch func() error) error {
+	start := time.Now()
+	err := fetch()
+
+	var decodeErr *decodeError
+	if !errors.As(err, &decodeErr) || c.MaxRetries <= 0 || ctx.Err() != nil {
+		return err
+	}
+
+	delay := c.backoffDelay(0)
+	if c.RetryBudget > 0 && time.Since(start)+delay > c.RetryBudget {
+		return err
+	}
+
+	tflog.Debug(ctx, "Refetching after response could not be decoded", map[string]any{
+		"path":     path,
+		"error":    err.Error(),
+		"delay_ms": delay.Milliseconds(),
+	})
+	if err := sleepContext(ctx, delay); err != nil {
+		return fmt.Errorf("request cancelled while waiting to retry: %w", err)
+	}
+
+	return fetch()
+}
+
+// backoffDelay returns the exponential backoff delay for the given attempt
+// (starting at 0), with jitter so parallel clients don't retry in lockstep
+func (c *Client) backoffDelay(attempt int) time.Duration {
+	baseDelay, maxDelay := c.RetryBaseDelay, c.RetryMaxDelay
+	if baseDelay <= 0 {
+		baseDelay = DefaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+
+	delay := baseDelay << attempt
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
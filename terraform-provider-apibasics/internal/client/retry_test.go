@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// flakyDNS makes the client's first n lookups fail with a temporary DNS
+// error before requests reach the server
+func flakyDNS(c *Client, n int32) *atomic.Int32 {
+	var failures atomic.Int32
+	next := c.HTTPClient.Transport
+	c.HTTPClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if failures.Add(1) <= n {
+			return nil, &net.DNSError{Err: "server misbehaving", Name: req.URL.Hostname(), IsTemporary: true}
+		}
+		return next.RoundTrip(req)
+	})
+	return &failures
+}
+
+func TestAuthenticateRetriesTemporaryDNSErrors(t *testing.T) {
+	var issuer tokenIssuer
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		issuer.serveToken(t, w, r)
+	})
+	c := newTestClient(srv)
+	c.Email, c.Password = "ada@example.com", "secret"
+	flakyDNS(c, 1)
+
+	if err := c.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate() error = %v, want the DNS failure retried", err)
+	}
+	if logins, _ := issuer.counts(); logins != 1 {
+		t.Errorf("logins = %d, want 1", logins)
+	}
+}
+
+func TestCreateTodoRetriesTemporaryDNSErrors(t *testing.T) {
+	var creates atomic.Int32
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		creates.Add(1)
+		writeJSON(t, w, http.StatusCreated, Todo{ID: testTodoID, Title: "Resolved"})
+	})
+	c := newTestClient(srv)
+	flakyDNS(c, 1)
+
+	// A failed lookup never reached the server, so even a create is retried
+	title := "Resolved"
+	if _, err := c.CreateTodo(context.Background(), TodoInput{Title: &title}); err != nil {
+		t.Fatalf("CreateTodo() error = %v, want the DNS failure retried", err)
+	}
+	if creates.Load() != 1 {
+		t.Errorf("creates = %d, want 1", creates.Load())
+	}
+}
+
+func TestTemporaryDNSErrorRetriesAreBounded(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the server")
+	})
+	c := newTestClient(srv)
+	c.MaxRetries = 2
+	lookups := flakyDNS(c, 100)
+
+	if _, err := c.GetTodo(context.Background(), testTodoID); !isTemporaryDNSError(err) {
+		t.Fatalf("GetTodo() error = %v, want the DNS error", err)
+	}
+	if lookups.Load() != 3 {
+		t.Errorf("lookups = %d, want MaxRetries+1 = 3", lookups.Load())
+	}
+}
+
+// truncatingServer answers the first truncated requests with a todo cut off
+// mid-body and later ones with the whole todo
+func truncatingServer(t *testing.T, truncated int32, calls *atomic.Int32) *Client {
+	t.Helper()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= truncated {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"` + testTodoID + `","tit`))
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Whole"})
+	})
+	return newTestClient(srv)
+}
+
+func TestGetTodoRefetchesTruncatedResponse(t *testing.T) {
+	var calls atomic.Int32
+	c := truncatingServer(t, 1, &calls)
+
+	todo, err := c.GetTodo(context.Background(), testTodoID)
+	if err != nil {
+		t.Fatalf("GetTodo() error = %v", err)
+	}
+	if todo.Title != "Whole" || calls.Load() != 2 {
+		t.Errorf("title = %q after %d calls, want %q after 2", todo.Title, calls.Load(), "Whole")
+	}
+}
+
+func TestGetTodoFailsOnConsistentlyMalformedJSON(t *testing.T) {
+	var calls atomic.Int32
+	c := truncatingServer(t, 100, &calls)
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	var decodeErr *decodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("GetTodo() error = %v, want a decode error", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want a single refetch", calls.Load())
+	}
+}
+
+// failFirst returns a handler that answers the first n requests with status
+// and the rest with a todo, counting requests in calls
+func failFirst(t *testing.T, n int32, status int, calls *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= n {
+			w.WriteHeader(status)
+			return
+		}
+		writeJSON(t, w, http.StatusOK, Todo{ID: testTodoID, Title: "Recovered"})
+	}
+}
+
+func TestGetTodoRetriesServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		var calls atomic.Int32
+		c := newTestClient(newTestServer(t, failFirst(t, 2, status, &calls)))
+
+		todo, err := c.GetTodo(context.Background(), testTodoID)
+		if err != nil {
+			t.Fatalf("GetTodo() after two %d responses error = %v", status, err)
+		}
+		if todo.Title != "Recovered" || calls.Load() != 3 {
+			t.Errorf("got %q after %d calls, want success on the third after %d", todo.Title, calls.Load(), status)
+		}
+	}
+}
+
+func TestServerErrorRetriesAreBounded(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(newTestServer(t, failFirst(t, 100, http.StatusServiceUnavailable, &calls)))
+	c.MaxRetries = 2
+
+	_, err := c.GetTodo(context.Background(), testTodoID)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GetTodo() error = %v, want the last 503", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+}
+
+func TestNonRetryableFailuresAreSentOnce(t *testing.T) {
+	title := "Write tests"
+	tests := []struct {
+		name   string
+		status int
+		call   func(c *Client) error
+	}{
+		{
+			// A retried create could make a duplicate todo
+			name:   "create after 503",
+			status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				_, err := c.CreateTodo(context.Background(), TodoInput{Title: &title})
+				return err
+			},
+		},
+		{
+			name:   "get after 400",
+			status: http.StatusBadRequest,
+			call: func(c *Client) error {
+				_, err := c.GetTodo(context.Background(), testTodoID)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			c := newTestClient(newTestServer(t, failFirst(t, 1, tt.status, &calls)))
+
+			if err := tt.call(c); err == nil {
+				t.Errorf("error = nil, want the %d", tt.status)
+			}
+			if got := calls.Load(); got != 1 {
+				t.Errorf("calls = %d, want 1", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignKnownRequest(t *testing.T) {
+	canonical := CanonicalRequest("post", "/todos?limit=2", time.Unix(1700000000, 0), []byte(`{"title":"Sign me"}`))
+	wantCanonical := "POST\n/todos?limit=2\n1700000000\n1a5d48f0287aa572e3dc6d532123508fc9b384171583338a2e8003f81af35781"
+	if canonical != wantCanonical {
+		t.Fatalf("CanonicalRequest() = %q, want %q", canonical, wantCanonical)
+	}
+
+	if got, want := Sign("shared-secret", canonical), "b307eecf9593b2425822d77b520337ebe31f009573418d7f0d581f28c47876e0"; got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestSignedRequestsKeepBearerToken(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request: %v", err)
+		}
+		seconds, err := strconv.ParseInt(r.Header.Get(SignatureTimestampHeader), 10, 64)
+		if err != nil {
+			t.Errorf("signature timestamp %q: %v", r.Header.Get(SignatureTimestampHeader), err)
+		}
+
+		// Verify the signature the way a gateway would
+		canonical := CanonicalRequest(r.Method, r.URL.RequestURI(), time.Unix(seconds, 0), body)
+		if got, want := r.Header.Get(SignatureHeader), Sign("shared-secret", canonical); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want the bearer token alongside the signature", got)
+		}
+		writeJSON(t, w, http.StatusCreated, Todo{ID: testTodoID, Title: "Sign me"})
+	})
+	c := newTestClient(srv)
+	c.SigningSecret = "shared-secret"
+
+	title := "Sign me"
+	if _, err := c.CreateTodo(context.Background(), TodoInput{Title: &title}); err != nil {
+		t.Fatalf("CreateTodo() error = %v", err)
+	}
+}
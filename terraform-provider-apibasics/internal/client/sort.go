@@ -0,0 +1,61 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortDirection is the order of one sort key
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// SortField is one key of a list sort. An empty Direction sorts ascending.
+type SortField struct {
+	Field     string
+	Direction SortDirection
+}
+
+// SortableFields are the todo fields the list endpoint can sort by
+var SortableFields = []string{"title", "completed", "createdAt", "updatedAt"}
+
+// encodeSort serializes sort keys as the sort query parameter, e.g.
+// "completed:asc,createdAt:desc". Later keys break ties in earlier ones.
+func encodeSort(sortBy []SortField) (string, error) {
+	parts := make([]string, 0, len(sortBy))
+	seen := make(map[string]struct{}, len(sortBy))
+	for _, key := range sortBy {
+		if !isSortableField(key.Field) {
+			return "", fmt.Errorf("cannot sort todos by %q, must be one of: %s", key.Field, strings.Join(SortableFields, ", "))
+		}
+		if _, ok := seen[key.Field]; ok {
+			return "", fmt.Errorf("todos are sorted by %q more than once", key.Field)
+		}
+		seen[key.Field] = struct{}{}
+
+		direction := key.Direction
+		switch direction {
+		case "":
+			direction = SortAscending
+		case SortAscending, SortDescending:
+		default:
+			return "", fmt.Errorf("invalid sort direction %q for %q, must be %q or %q", key.Direction, key.Field, SortAscending, SortDescending)
+		}
+
+		parts = append(parts, key.Field+":"+string(direction))
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// isSortableField reports whether the list endpoint can sort by field
+func isSortableField(field string) bool {
+	for _, sortable := range SortableFields {
+		if field == sortable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEncodeSort(t *testing.T) {
+	got, err := encodeSort([]SortField{
+		{Field: "completed", Direction: SortDescending},
+		{Field: "createdAt"},
+	})
+	if err != nil {
+		t.Fatalf("encodeSort() error = %v", err)
+	}
+	if want := "completed:desc,createdAt:asc"; got != want {
+		t.Errorf("encodeSort() = %q, want %q", got, want)
+	}
+}
+
+func TestEncodeSortRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		sortBy []SortField
+		want   string
+	}{
+		{name: "unknown field", sortBy: []SortField{{Field: "priority"}}, want: "cannot sort todos by"},
+		{name: "repeated field", sortBy: []SortField{{Field: "title"}, {Field: "title", Direction: SortDescending}}, want: "more than once"},
+		{name: "bad direction", sortBy: []SortField{{Field: "title", Direction: "up"}}, want: "invalid sort direction"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := encodeSort(tt.sortBy); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("encodeSort() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListTodosSendsSort(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("sort"), "updatedAt:desc,title:asc"; got != want {
+			t.Errorf("sort = %q, want %q", got, want)
+		}
+		writeJSON(t, w, http.StatusOK, []Todo{})
+	})
+	c := newTestClient(srv)
+
+	_, err := c.ListTodos(context.Background(), ListOptions{SortBy: []SortField{
+		{Field: "updatedAt", Direction: SortDescending},
+		{Field: "title", Direction: SortAscending},
+	}})
+	if err != nil {
+		t.Fatalf("ListTodos() error = %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// User represents a user account
+type User struct {
+	ID        string `json:"id,omitempty"`
+	Email     string `json:"email"`
+	Name      string `json:"name,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// GetProfile retrieves the user the client is authenticated as. The API
+// only exposes the caller's own profile, so other users cannot be looked up.
+func (c *Client) GetProfile(ctx context.Context) (*User, error) {
+	resp, err := c.DoRequestWithOptions(ctx, "GET", "/profile", nil, RequestOptions{Operation: "get profile"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("get profile", resp)
+	}
+
+	var user User
+	if err := c.decodeJSON(resp.Body, &user); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &user, nil
+}
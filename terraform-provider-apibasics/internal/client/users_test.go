@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetProfile(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/profile" {
+			t.Errorf("request = %s %s, want GET /profile", r.Method, r.URL.Path)
+		}
+		writeJSON(t, w, http.StatusOK, map[string]any{
+			"id":          testUserID,
+			"email":       "ada@example.com",
+			"name":        "Ada",
+			"bio":         "",
+			"preferences": map[string]any{},
+			"createdAt":   "2024-05-01T10:00:00Z",
+		})
+	})
+
+	user, err := newTestClient(srv).GetProfile(context.Background())
+	if err != nil {
+		t.Fatalf("GetProfile() error = %v", err)
+	}
+	if user.ID != testUserID || user.Email != "ada@example.com" || user.Name != "Ada" {
+		t.Errorf("user = %+v, want the authenticated user", user)
+	}
+}
+
+func TestGetProfileError(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "User not found"})
+	})
+
+	_, err := newTestClient(srv).GetProfile(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetProfile() error = %v, want the 404", err)
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Polling intervals used by WaitForCompletion
+const (
+	completionPollBaseDelay = time.Second
+	completionPollMaxDelay  = 30 * time.Second
+)
+
+// WaitForCompletion blocks until the todo with id is completed and returns
+// it, or until ctx is done. Each check asks the API to long-poll with
+// ?wait=true; servers that don't support it answer immediately, in which
+// case checks are spaced out with exponential backoff.
+func (c *Client) WaitForCompletion(ctx context.Context, id string) (*Todo, error) {
+	if err := validateTodoID(id); err != nil {
+		return nil, err
+	}
+
+	delay := completionPollBaseDelay
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		todo, err := c.getTodoWaiting(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if todo.Completed {
+			tflog.Debug(ctx, "Todo completed", map[string]any{"id": id, "checks": attempt})
+			return todo, nil
+		}
+
+		// A long poll that was held open already spent the wait
+		if remaining := delay - time.Since(start); remaining > 0 {
+			tflog.Debug(ctx, "Waiting for todo to be completed", map[string]any{"id": id, "attempt": attempt, "delay_ms": remaining.Milliseconds()})
+			if err := sleepContext(ctx, remaining); err != nil {
+				return nil, fmt.Errorf("stopped waiting for todo %s to be completed: %w", id, err)
+			}
+		}
+
+		delay *= 2
+		if delay > completionPollMaxDelay {
+			delay = completionPollMaxDelay
+		}
+	}
+}
+
+// getTodoWaiting fetches a todo with a long-poll hint, bypassing the read
+// cache since the caller is waiting for it to change
+func (c *Client) getTodoWaiting(ctx context.Context, id string) (*Todo, error) {
+	resp, err := c.DoRequestWithOptions(ctx, "GET", "/todos/"+id+"?wait=true", nil, RequestOptions{Operation: "get todo"})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError("get todo", resp)
+	}
+
+	var todo Todo
+	if err := c.decodeJSON(resp.Body, &todo); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &todo, nil
+}
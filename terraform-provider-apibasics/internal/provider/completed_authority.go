@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// completedAuthority is who owns a todo's completed flag.
+type completedAuthority string
+
+const (
+	// completedAuthorityTerraform manages completed like any other attribute.
+	completedAuthorityTerraform completedAuthority = "terraform"
+
+	// completedAuthorityExternal leaves completed to another system, so
+	// the provider only reports the server's value.
+	completedAuthorityExternal completedAuthority = "external"
+)
+
+// planExternalCompletion keeps completed as the server last reported it
+// when another system owns it, so an external change never shows up as a
+// diff. Terraform requires planned values to match the configuration, so
+// setting completed is rejected instead of silently overridden.
+func planExternalCompletion(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("completed"), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !configured.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("completed"),
+			"Completion Managed Externally",
+			"completed cannot be set while the provider's completed_authority is \"external\"; remove it from the configuration "+
+				"or set completed_authority = \"terraform\".",
+		)
+		return
+	}
+
+	// New todos keep the create default from the attribute's plan modifier
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var current types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("completed"), &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("completed"), current)...)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCompletedAuthority(t *testing.T) {
+	tests := []struct {
+		name      string
+		authority completedAuthority
+		configure types.Bool
+		planned   types.Bool
+		want      types.Bool
+		wantError string
+	}{
+		// The todo was completed elsewhere and refreshed into state as true
+		{name: "terraform reverts the external flip", authority: completedAuthorityTerraform, configure: types.BoolValue(false), planned: types.BoolValue(false), want: types.BoolValue(false)},
+		{name: "external keeps the server value", authority: completedAuthorityExternal, configure: types.BoolNull(), planned: types.BoolUnknown(), want: types.BoolValue(true)},
+		{name: "external rejects a configured value", authority: completedAuthorityExternal, configure: types.BoolValue(false), planned: types.BoolValue(false), wantError: "Completion Managed Externally"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestTodoResource(t, nil)
+			r.completedAuthority = tt.authority
+			s := todoResourceSchema(t, r)
+
+			state := storedTodo()
+			config := state
+			config.ID, config.UserID, config.CreatedAt, config.UpdatedAt = types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull()
+			config.ModifiedBy, config.RawJSON, config.CompletedAt = types.StringNull(), types.StringNull(), types.StringNull()
+			config.Completed = tt.configure
+			plan := state
+			plan.Completed = tt.planned
+			plan.CompletedAt = types.StringUnknown()
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: todoObject(t, r, config)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: todoObject(t, r, plan)},
+				State:  tfsdk.State{Schema: s, Raw: todoObject(t, r, state)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if tt.wantError != "" {
+				if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary() != tt.wantError {
+					t.Errorf("diagnostics = %v, want a single %q error", resp.Diagnostics, tt.wantError)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan() diagnostics = %v", resp.Diagnostics)
+			}
+			var completed types.Bool
+			resp.Plan.GetAttribute(context.Background(), path.Root("completed"), &completed)
+			if !completed.Equal(tt.want) {
+				t.Errorf("planned completed = %v, want %v", completed, tt.want)
+			}
+			if noChange := resp.Plan.Raw.Equal(req.State.Raw); noChange != (tt.authority == completedAuthorityExternal) {
+				t.Errorf("plan matches state = %t, want an update only when Terraform owns completion", noChange)
+			}
+		})
+	}
+}
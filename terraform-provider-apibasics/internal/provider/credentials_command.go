@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// commandCredentials is the JSON a credentials_command prints on stdout.
+type commandCredentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+}
+
+// runCredentialsCommand runs argv and parses the credentials it prints. The
+// command's stdout is never included in errors since it holds secrets.
+func runCredentialsCommand(ctx context.Context, argv []string) (*commandCredentials, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return nil, errors.New("the command must not be empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%s exited with status %d: %s", argv[0], exitErr.ExitCode(), strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("could not run %s: %w", argv[0], err)
+	}
+
+	var creds commandCredentials
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("%s did not print a JSON object with email, password or token fields: %v", argv[0], err)
+	}
+	if creds.Token == "" && (creds.Email == "" || creds.Password == "") {
+		return nil, fmt.Errorf("%s must print either a token, or both an email and a password", argv[0])
+	}
+
+	return &creds, nil
+}
@@ -0,0 +1,35 @@
+package provider
+
+import "strings"
+
+// descriptionAffixes are the provider-level description_prefix and
+// description_suffix added to every todo description the provider writes.
+// They are removed again when reading a todo, so state and plans only ever
+// hold the configured description and no diff is produced by the affixes.
+// A todo created without a description is left with the server's default,
+// which carries no affixes, and no update adds them until a description is
+// configured.
+type descriptionAffixes struct {
+	prefix string
+	suffix string
+}
+
+// apply decorates a configured description before it is sent to the API.
+func (a descriptionAffixes) apply(description string) string {
+	return a.prefix + description + a.suffix
+}
+
+// strip recovers the configured description from one returned by the API.
+// A description that doesn't carry both affixes, for example one written
+// before they were configured, is returned unchanged and picks them up the
+// next time its description is updated.
+func (a descriptionAffixes) strip(description string) string {
+	if a.prefix == "" && a.suffix == "" {
+		return description
+	}
+	if !strings.HasPrefix(description, a.prefix) || !strings.HasSuffix(description, a.suffix) ||
+		len(description) < len(a.prefix)+len(a.suffix) {
+		return description
+	}
+	return description[len(a.prefix) : len(description)-len(a.suffix)]
+}
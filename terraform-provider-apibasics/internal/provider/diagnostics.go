@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// DiagnosticMapper converts errors returned by the API client into
+// diagnostics. Supply a custom implementation with WithDiagnosticMapper to
+// change how failures are presented, for example to attach documentation
+// links or to downgrade specific status codes to warnings.
+type DiagnosticMapper interface {
+	MapError(ec ErrorContext, err error) diag.Diagnostics
+}
+
+// ErrorContext describes the operation that produced an error.
+type ErrorContext struct {
+	// Operation is the CRUD operation, e.g. "create" or "read".
+	Operation string
+	// Resource is the kind of object being operated on, e.g. "todo".
+	Resource string
+	// ID identifies the object when known.
+	ID string
+}
+
+// defaultDiagnosticMapper reports every error as a single error diagnostic.
+type defaultDiagnosticMapper struct{}
+
+// operationGerunds maps CRUD operations to the form used in summaries.
+var operationGerunds = map[string]string{
+	"create": "Creating",
+	"read":   "Reading",
+	"update": "Updating",
+	"delete": "Deleting",
+}
+
+// MapError implements DiagnosticMapper.
+func (defaultDiagnosticMapper) MapError(ec ErrorContext, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	gerund, ok := operationGerunds[ec.Operation]
+	if !ok {
+		gerund = capitalize(ec.Operation)
+	}
+	summary := fmt.Sprintf("Error %s %s", gerund, capitalize(ec.Resource))
+
+	target := ec.Resource
+	if ec.ID != "" {
+		target += " ID " + ec.ID
+	}
+
+	if client.IsForbidden(err) {
+		diags.AddError(
+			fmt.Sprintf("Permission Denied %s %s", gerund, capitalize(ec.Resource)),
+			fmt.Sprintf("The API accepted the provider's credentials but they are not allowed to %s %s. "+
+				"Check that the account has permission for this operation. Error: %s", ec.Operation, target, err.Error()),
+		)
+		return diags
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.Message != "" {
+		detail := fmt.Sprintf("Could not %s %s: the API returned status %d: %s", ec.Operation, target, apiErr.StatusCode, apiErr.Message)
+		if apiErr.RequestID != "" {
+			detail += fmt.Sprintf(" (request ID %s)", apiErr.RequestID)
+		}
+		diags.AddError(summary, detail)
+		return diags
+	}
+
+	diags.AddError(summary, fmt.Sprintf("Could not %s %s, unexpected error: %s", ec.Operation, target, err.Error()))
+	return diags
+}
+
+// capitalize upper-cases the first letter of an ASCII word.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+)
+
+func TestDefaultDiagnosticMapper(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantSummary string
+		wantDetail  string
+	}{
+		{
+			name:        "forbidden",
+			err:         &client.APIError{Operation: "update todo", StatusCode: http.StatusForbidden, Message: "not allowed"},
+			wantSummary: "Permission Denied Updating Todo",
+			wantDetail:  "not allowed to update todo ID 1",
+		},
+		{
+			name:        "api message",
+			err:         &client.APIError{Operation: "update todo", StatusCode: http.StatusBadRequest, Message: "title too long", RequestID: "req-1"},
+			wantSummary: "Error Updating Todo",
+			wantDetail:  "status 400: title too long (request ID req-1)",
+		},
+		{
+			name:        "other error",
+			err:         errors.New("connection reset"),
+			wantSummary: "Error Updating Todo",
+			wantDetail:  "unexpected error: connection reset",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := defaultDiagnosticMapper{}.MapError(ErrorContext{Operation: "update", Resource: "todo", ID: "1"}, tt.err)
+			if len(diags) != 1 || !diags.HasError() {
+				t.Fatalf("diagnostics = %v, want a single error", diags)
+			}
+			if got := diags[0].Summary(); got != tt.wantSummary {
+				t.Errorf("summary = %q, want %q", got, tt.wantSummary)
+			}
+			if got := diags[0].Detail(); !strings.Contains(got, tt.wantDetail) {
+				t.Errorf("detail = %q, want it to contain %q", got, tt.wantDetail)
+			}
+		})
+	}
+}
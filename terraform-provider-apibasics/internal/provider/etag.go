@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// etagPrivateKey is the private state key holding the ETag a todo was last
+// served with, for conditional deletes.
+const etagPrivateKey = "etag"
+
+// privateStateSetter is the private state of a resource response.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// privateStateGetter is the private state of a resource request.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// storeETag records etag in private state, removing any previous value when
+// the API sent none.
+func storeETag(ctx context.Context, private privateStateSetter, etag string) diag.Diagnostics {
+	if etag == "" {
+		return private.SetKey(ctx, etagPrivateKey, nil)
+	}
+
+	// Private state values must be JSON
+	value, err := json.Marshal(etag)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error Storing ETag", "Could not encode the todo ETag: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, etagPrivateKey, value)
+}
+
+// storedETag returns the ETag recorded by storeETag, or an empty string.
+func storedETag(ctx context.Context, private privateStateGetter) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, etagPrivateKey)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+
+	var etag string
+	if err := json.Unmarshal(value, &etag); err != nil {
+		// Not fatal: the delete just goes ahead unconditionally
+		return "", diags
+	}
+	return etag, diags
+}
+
+// versionPrivateKey is the private state key holding the version a todo was
+// last read at, for conditional updates.
+const versionPrivateKey = "version"
+
+// storeVersion records version in private state, removing any previous
+// value when the API does not version todos.
+func storeVersion(ctx context.Context, private privateStateSetter, version int) diag.Diagnostics {
+	if version == 0 {
+		return private.SetKey(ctx, versionPrivateKey, nil)
+	}
+
+	value, err := json.Marshal(version)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error Storing Version", "Could not encode the todo version: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, versionPrivateKey, value)
+}
+
+// storedVersion returns the version recorded by storeVersion, or zero.
+func storedVersion(ctx context.Context, private privateStateGetter) (int, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, versionPrivateKey)
+	if diags.HasError() || len(value) == 0 {
+		return 0, diags
+	}
+
+	var version int
+	if err := json.Unmarshal(value, &version); err != nil {
+		// Not fatal: the update just goes ahead unconditionally
+		return 0, diags
+	}
+	return version, diags
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &healthDataSource{}
+	_ datasource.DataSourceWithConfigure = &healthDataSource{}
+)
+
+// NewHealthDataSource is a helper function to simplify the provider implementation.
+func NewHealthDataSource() datasource.DataSource {
+	return &healthDataSource{}
+}
+
+// healthDataSource is the data source implementation.
+type healthDataSource struct {
+	client *client.Client
+}
+
+// healthDataSourceModel maps the data source schema data.
+type healthDataSourceModel struct {
+	FailIfUnreachable types.Bool   `tfsdk:"fail_if_unreachable"`
+	Reachable         types.Bool   `tfsdk:"reachable"`
+	Status            types.String `tfsdk:"status"`
+	LatencyMs         types.Int64  `tfsdk:"latency_ms"`
+}
+
+// Metadata returns the data source type name.
+func (d *healthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_health"
+}
+
+// Schema defines the schema for the data source.
+func (d *healthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Probes the health of the API, for gating resources or monitoring from Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"fail_if_unreachable": schema.BoolAttribute{
+				Description: "Fail the read when the API cannot be reached, instead of setting reachable to false. Defaults to false.",
+				Optional:    true,
+			},
+			"reachable": schema.BoolAttribute{
+				Description: "Whether the API answered the probe.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status reported by the API, or \"ok\" or \"unhealthy\" based on the response code. \"unreachable\" when the API could not be reached.",
+				Computed:    true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Description: "Round-trip time of the probe in milliseconds. Null when the API could not be reached.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *healthDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *healthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state healthDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	health, err := d.client.Ping(ctx)
+	if err != nil {
+		if state.FailIfUnreachable.ValueBool() {
+			resp.Diagnostics.AddError(
+				"API Unreachable",
+				"Could not reach the API health endpoint: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Warn(ctx, "API health probe failed", map[string]any{"error": err.Error()})
+		state.Reachable = types.BoolValue(false)
+		state.Status = types.StringValue("unreachable")
+		state.LatencyMs = types.Int64Null()
+	} else {
+		state.Reachable = types.BoolValue(true)
+		state.Status = types.StringValue(health.Status)
+		state.LatencyMs = types.Int64Value(health.Latency.Milliseconds())
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Probed API health", map[string]any{"reachable": state.Reachable.ValueBool(), "status": state.Status.ValueString()})
+}
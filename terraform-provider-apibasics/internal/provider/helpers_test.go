@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testTodoID is a valid todo UUID
+const testTodoID = "0b5a7c9e-1f2d-4e3a-8b6c-7d9e0f1a2b3c"
+
+// newTestTodoResource returns a todo resource whose client talks to
+// handler, which the test fails on if it is nil and called
+func newTestTodoResource(t *testing.T, handler http.HandlerFunc) *todoResource {
+	t.Helper()
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected API request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := client.NewClient(srv.URL, "", "")
+	c.AccessToken = "test-token"
+	c.MaxRetries = 0
+
+	r := NewTodoResource().(*todoResource)
+	r.client = c
+	return r
+}
+
+// todoResourceSchema returns the schema of the todo resource
+func todoResourceSchema(t *testing.T, r *todoResource) schema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// todoObject converts a todo model into a value of the resource schema
+func todoObject(t *testing.T, r *todoResource, model todoResourceModel) tftypes.Value {
+	t.Helper()
+	s := todoResourceSchema(t, r)
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}
+	if diags := state.Set(context.Background(), &model); diags.HasError() {
+		t.Fatalf("building todo value: %v", diags)
+	}
+	return state.Raw
+}
+
+// storedTodo returns the model of a todo as Read would store it
+func storedTodo() todoResourceModel {
+	return todoResourceModel{
+		ID:          types.StringValue(testTodoID),
+		Title:       types.StringValue("Write tests"),
+		Description: types.StringValue(""),
+		Completed:   types.BoolValue(true),
+		CompletedAt: types.StringValue("2024-05-01T12:00:00Z"),
+		UserID:      types.StringValue("2d7c9e1a-3f4a-4b6c-8d8e-9f1a2b3c4d5e"),
+		CreatedAt:   types.StringValue("2024-05-01T10:00:00Z"),
+		UpdatedAt:   types.StringValue("2024-05-01T12:00:00Z"),
+		Metadata:    types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("core")}),
+		ParentID:    types.StringNull(),
+		ModifiedBy:  types.StringNull(),
+		RawJSON:     types.StringNull(),
+
+		EndpointOverride: types.StringNull(),
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultImportableMaxItems caps the number of ids returned when max_items is not set.
+const defaultImportableMaxItems = 1000
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &importableTodosDataSource{}
+	_ datasource.DataSourceWithConfigure = &importableTodosDataSource{}
+)
+
+// NewImportableTodosDataSource is a helper function to simplify the provider implementation.
+func NewImportableTodosDataSource() datasource.DataSource {
+	return &importableTodosDataSource{}
+}
+
+// importableTodosDataSource is the data source implementation.
+type importableTodosDataSource struct {
+	client *client.Client
+}
+
+// importableTodosDataSourceModel maps the data source schema data.
+type importableTodosDataSourceModel struct {
+	MaxItems  types.Int64 `tfsdk:"max_items"`
+	IDs       types.List  `tfsdk:"ids"`
+	Truncated types.Bool  `tfsdk:"truncated"`
+}
+
+// Metadata returns the data source type name.
+func (d *importableTodosDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_importable_todos"
+}
+
+// Schema defines the schema for the data source.
+func (d *importableTodosDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the ids of all existing todos so they can be adopted with import blocks.",
+		Attributes: map[string]schema.Attribute{
+			"max_items": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of ids to return. Defaults to %d.", defaultImportableMaxItems),
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "UUIDs of the todos, suitable for use as import block ids.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"truncated": schema.BoolAttribute{
+				Description: "Whether more todos exist than were returned because of max_items.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *importableTodosDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *importableTodosDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state importableTodosDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxItems := int64(defaultImportableMaxItems)
+	if !state.MaxItems.IsNull() {
+		maxItems = state.MaxItems.ValueInt64()
+	}
+	if maxItems < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_items"),
+			"Invalid Max Items",
+			"max_items must be at least 1.",
+		)
+		return
+	}
+
+	// Walk every page, stopping as soon as one todo past the cap is seen
+	ids := []string{}
+	truncated := false
+	err := d.client.ListTodosFunc(ctx, client.ListOptions{}, func(todo client.Todo) error {
+		if int64(len(ids)) >= maxItems {
+			truncated = true
+			return client.ErrStopIteration
+		}
+		ids = append(ids, todo.ID)
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Todos",
+			"Could not list todos, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	if truncated {
+		resp.Diagnostics.AddWarning(
+			"Todo List Truncated",
+			fmt.Sprintf("More than %d todos exist; only the first %d ids were returned. Increase max_items to include the rest.", maxItems, maxItems),
+		)
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.IDs = idList
+	state.Truncated = types.BoolValue(truncated)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Listed importable todos", map[string]any{"count": len(ids), "truncated": truncated})
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// missingFieldMode is how Read treats a field the API stopped returning.
+type missingFieldMode string
+
+const (
+	// missingFieldKeep keeps the value from the prior state.
+	missingFieldKeep missingFieldMode = "keep"
+
+	// missingFieldClear stores the empty value, as if the server had sent it.
+	missingFieldClear missingFieldMode = "clear"
+
+	// missingFieldWarn keeps the prior value and reports a warning.
+	missingFieldWarn missingFieldMode = "warn"
+)
+
+// requiredTodoFields are the fields every todo response is expected to
+// carry, with the attribute each populates. Optional fields such as
+// parent_id or metadata are omitted by the API when empty, so their absence
+// is not a schema change.
+var requiredTodoFields = []struct {
+	field     string
+	attribute string
+	restore   func(state, prior *todoResourceModel)
+}{
+	{"title", "title", func(state, prior *todoResourceModel) { state.Title = prior.Title }},
+	{"description", "description", func(state, prior *todoResourceModel) { state.Description = prior.Description }},
+	{"completed", "completed", func(state, prior *todoResourceModel) { state.Completed = prior.Completed }},
+	{"userId", "user_id", func(state, prior *todoResourceModel) { state.UserID = prior.UserID }},
+	{"createdAt", "created_at", func(state, prior *todoResourceModel) { state.CreatedAt = prior.CreatedAt }},
+	{"updatedAt", "updated_at", func(state, prior *todoResourceModel) { state.UpdatedAt = prior.UpdatedAt }},
+}
+
+// apply handles the required fields missing from todo after it has been
+// copied into state, restoring them from prior for keep and warn.
+func (m missingFieldMode) apply(todo *client.Todo, prior, state *todoResourceModel, diags *diag.Diagnostics) {
+	if m != missingFieldKeep && m != missingFieldWarn {
+		return
+	}
+
+	for _, f := range requiredTodoFields {
+		if todo.HasField(f.field) {
+			continue
+		}
+
+		f.restore(state, prior)
+		if m == missingFieldWarn {
+			diags.AddAttributeWarning(
+				path.Root(f.attribute),
+				"Field Missing From API Response",
+				"The API did not return the "+f.field+" field for todo ID "+todo.ID+", so the previous value was kept. "+
+					"The server may have dropped support for it.",
+			)
+		}
+	}
+}
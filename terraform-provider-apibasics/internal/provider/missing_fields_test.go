@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMissingFieldModes(t *testing.T) {
+	// A server that dropped description from its responses
+	var todo client.Todo
+	body := `{"id":"` + testTodoID + `","title":"Write tests","completed":true,"userId":"u","createdAt":"2024-05-01T10:00:00Z","updatedAt":"2024-05-01T12:00:00Z"}`
+	if err := json.Unmarshal([]byte(body), &todo); err != nil {
+		t.Fatal(err)
+	}
+
+	prior := storedTodo()
+	prior.Description = types.StringValue("kept from state")
+
+	tests := []struct {
+		mode        missingFieldMode
+		description string
+		warnings    int
+	}{
+		{mode: missingFieldKeep, description: "kept from state"},
+		{mode: missingFieldClear, description: ""},
+		{mode: missingFieldWarn, description: "kept from state", warnings: 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			state := prior
+			var diags diag.Diagnostics
+			diags.Append(state.setFromTodo(context.Background(), &todo, descriptionAffixes{})...)
+			tt.mode.apply(&todo, &prior, &state, &diags)
+
+			if got := state.Description.ValueString(); got != tt.description {
+				t.Errorf("description = %q, want %q", got, tt.description)
+			}
+			if got := state.Title.ValueString(); got != "Write tests" {
+				t.Errorf("title = %q, want the value the server sent", got)
+			}
+			if diags.HasError() || diags.WarningsCount() != tt.warnings {
+				t.Errorf("diagnostics = %v, want %d warnings", diags, tt.warnings)
+			}
+		})
+	}
+}
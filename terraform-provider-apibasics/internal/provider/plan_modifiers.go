@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// boolDefaultOnCreate returns a plan modifier that plans value for an
+// unconfigured attribute only when the resource is being created. Once the
+// resource exists, leaving the attribute unset keeps whatever the server
+// reports, so importing a todo doesn't plan its value back to the default.
+func boolDefaultOnCreate(value bool) planmodifier.Bool {
+	return boolDefaultOnCreateModifier{value: value}
+}
+
+// boolDefaultOnCreateModifier implements boolDefaultOnCreate.
+type boolDefaultOnCreateModifier struct {
+	value bool
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m boolDefaultOnCreateModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Defaults to %t when the resource is created; otherwise an unset value keeps the current state.", m.value)
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m boolDefaultOnCreateModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyBool implements the plan modification logic.
+func (m boolDefaultOnCreateModifier) PlanModifyBool(_ context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	// Nothing to do on destroy or when the practitioner set a value
+	if req.Plan.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.PlanValue = types.BoolValue(m.value)
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
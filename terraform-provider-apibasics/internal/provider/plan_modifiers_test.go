@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// completedObjectType is a resource whose only attribute is completed
+var completedObjectType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"completed": tftypes.Bool}}
+
+func TestBoolDefaultOnCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  types.Bool // null when the resource is being created
+		config types.Bool
+		want   types.Bool
+	}{
+		{name: "create uses the default", state: types.BoolNull(), config: types.BoolNull(), want: types.BoolValue(false)},
+		{name: "imported completed todo keeps its value", state: types.BoolValue(true), config: types.BoolNull(), want: types.BoolValue(true)},
+		{name: "configured value wins", state: types.BoolValue(true), config: types.BoolValue(false), want: types.BoolValue(false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateRaw := tftypes.NewValue(completedObjectType, nil)
+			if !tt.state.IsNull() {
+				stateRaw = tftypes.NewValue(completedObjectType, map[string]tftypes.Value{
+					"completed": tftypes.NewValue(tftypes.Bool, tt.state.ValueBool()),
+				})
+			}
+			planValue := types.BoolUnknown()
+			if !tt.config.IsNull() {
+				planValue = tt.config
+			}
+
+			req := planmodifier.BoolRequest{
+				ConfigValue: tt.config,
+				PlanValue:   planValue,
+				StateValue:  tt.state,
+				Plan: tfsdk.Plan{Raw: tftypes.NewValue(completedObjectType, map[string]tftypes.Value{
+					"completed": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+				})},
+				State: tfsdk.State{Raw: stateRaw},
+			}
+			resp := &planmodifier.BoolResponse{PlanValue: req.PlanValue}
+			boolDefaultOnCreate(false).PlanModifyBool(context.Background(), req, resp)
+
+			if !resp.PlanValue.Equal(tt.want) {
+				t.Errorf("planned %v, want %v", resp.PlanValue, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/api-basics/terraform-provider-apibasics/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,24 +24,103 @@ var (
 )
 
 // New is a helper function to simplify provider server and testing implementation.
-func New(version string) func() provider.Provider {
+func New(version string, opts ...Option) func() provider.Provider {
 	return func() provider.Provider {
-		return &apibasicsProvider{
-			version: version,
+		p := &apibasicsProvider{
+			version:          version,
+			diagnosticMapper: defaultDiagnosticMapper{},
 		}
+		for _, opt := range opts {
+			opt(p)
+		}
+		return p
+	}
+}
+
+// Option customises the provider returned by New.
+type Option func(*apibasicsProvider)
+
+// WithDiagnosticMapper replaces the default conversion of API errors into diagnostics.
+func WithDiagnosticMapper(m DiagnosticMapper) Option {
+	return func(p *apibasicsProvider) {
+		p.diagnosticMapper = m
 	}
 }
 
-// apibasicsProvider is the provider implementation.
+// apibasicsProvider is the provider implementation. Terraform creates one
+// per provider block, including each alias, and Configure builds a new
+// client for it, so nothing here may be shared between instances.
 type apibasicsProvider struct {
-	version string
+	version          string
+	diagnosticMapper DiagnosticMapper
+}
+
+// providerData is handed to resources and data sources by Configure.
+type providerData struct {
+	client             *client.Client
+	diagnostics        DiagnosticMapper
+	descriptionAffixes descriptionAffixes
+	workspace          workspaceOwnership
+	conditionalDelete  bool
+	tolerateReadErrors bool
+	sparseReads        bool
+	onMissingField     missingFieldMode
+	completedAuthority completedAuthority
 }
 
 // apibasicsProviderModel maps provider schema data to a Go type.
 type apibasicsProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Email    types.String `tfsdk:"email"`
-	Password types.String `tfsdk:"password"`
+	Endpoint   types.String `tfsdk:"endpoint"`
+	Email      types.String `tfsdk:"email"`
+	Password   types.String `tfsdk:"password"`
+	TokenPath  types.String `tfsdk:"token_path"`
+	AuthScheme types.String `tfsdk:"auth_scheme"`
+
+	CredentialsCommand types.List `tfsdk:"credentials_command"`
+	DisableEnvFallback types.Bool `tfsdk:"disable_env_fallback"`
+
+	APIKey     types.String `tfsdk:"api_key"`
+	APIKeyMode types.String `tfsdk:"api_key_mode"`
+
+	SlowRequestThreshold  types.String `tfsdk:"slow_request_threshold"`
+	PerRequestTimeout     types.String `tfsdk:"per_request_timeout"`
+	DialTimeout           types.String `tfsdk:"dial_timeout"`
+	TLSHandshakeTimeout   types.String `tfsdk:"tls_handshake_timeout"`
+	ConfirmNotFound       types.Bool   `tfsdk:"confirm_not_found"`
+	CompletedEncoding     types.String `tfsdk:"completed_encoding"`
+	LenientDecode         types.Bool   `tfsdk:"lenient_decode"`
+	RequestPriority       types.String `tfsdk:"request_priority"`
+	SendRequestIDs        types.Bool   `tfsdk:"send_request_ids"`
+	EnableReadCache       types.Bool   `tfsdk:"enable_read_cache"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	ConditionalDelete     types.Bool   `tfsdk:"conditional_delete"`
+	TolerateReadErrors    types.Bool   `tfsdk:"tolerate_read_errors"`
+	SparseReads           types.Bool   `tfsdk:"sparse_reads"`
+
+	RetryableErrorMessages types.List `tfsdk:"retryable_error_messages"`
+	FollowAsync            types.Bool `tfsdk:"follow_async"`
+	PreferMinimalResponse  types.Bool `tfsdk:"prefer_minimal_response"`
+
+	BatchUpdates types.Bool   `tfsdk:"batch_updates"`
+	BatchWindow  types.String `tfsdk:"batch_window"`
+
+	DescriptionPrefix types.String `tfsdk:"description_prefix"`
+	DescriptionSuffix types.String `tfsdk:"description_suffix"`
+
+	WorkspaceID       types.String `tfsdk:"workspace_id"`
+	WorkspaceMismatch types.String `tfsdk:"workspace_mismatch"`
+
+	OnMissingField     types.String `tfsdk:"on_missing_field"`
+	CompletedAuthority types.String `tfsdk:"completed_authority"`
+
+	SensitiveFields types.List   `tfsdk:"sensitive_fields"`
+	SigningSecret   types.String `tfsdk:"signing_secret"`
+
+	ResilienceProfile types.String `tfsdk:"resilience_profile"`
+	MaxRetries        types.Int64  `tfsdk:"max_retries"`
+	RetryBaseDelay    types.String `tfsdk:"retry_base_delay"`
+	RetryMaxDelay     types.String `tfsdk:"retry_max_delay"`
+	RetryBudget       types.String `tfsdk:"retry_budget"`
 }
 
 // Metadata returns the provider type name.
@@ -62,6 +147,189 @@ func (p *apibasicsProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"signing_secret": schema.StringAttribute{
+				Description: "Shared secret used to sign every request with an HMAC-SHA256 X-Signature header, for gateways that require signed requests. " +
+					"The bearer token is still sent. May also be provided via APIBASICS_SIGNING_SECRET environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"credentials_command": schema.ListAttribute{
+				Description: "Command and arguments to run to obtain credentials, e.g. from a secrets manager CLI. " +
+					"It must print a JSON object with either \"token\", or \"email\" and \"password\". Cannot be combined with email or password.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"disable_env_fallback": schema.BoolAttribute{
+				Description: "Ignore the APIBASICS_* environment variables, so that every setting must come from the provider configuration. " +
+					"Guards against picking up stray credentials. Defaults to false.",
+				Optional: true,
+			},
+			"api_key": schema.StringAttribute{
+				Description: "API key sent as the api_key query parameter on every request, for deployments that accept it instead of a bearer token. " +
+					"May also be provided via APIBASICS_API_KEY environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"api_key_mode": schema.StringAttribute{
+				Description: "How api_key is used: \"only\" sends it instead of logging in, so no email or password is needed; " +
+					"\"with_bearer\" also logs in and sends the bearer token. Defaults to \"only\".",
+				Optional: true,
+			},
+			"token_path": schema.StringAttribute{
+				Description: "Path of the token endpoint used to authenticate, relative to the endpoint. Defaults to \"/token\".",
+				Optional:    true,
+			},
+			"auth_scheme": schema.StringAttribute{
+				Description: "Scheme the access token is sent with in the Authorization header, for servers that expect a non-standard one. " +
+					"Defaults to the token_type the token endpoint returns, or \"Bearer\" when it returns none.",
+				Optional: true,
+			},
+			"slow_request_threshold": schema.StringAttribute{
+				Description: "Log a warning for any API request slower than this duration (e.g. \"2s\"). Disabled when unset or zero.",
+				Optional:    true,
+			},
+			"request_priority": schema.StringAttribute{
+				Description: "Priority hint sent to the API gateway on every request: \"high\", \"normal\" or \"low\". Defaults to \"normal\", which sends no header.",
+				Optional:    true,
+			},
+			"send_request_ids": schema.BoolAttribute{
+				Description: "Send a random X-Request-ID header with every request and include the request ID in API error messages, " +
+					"so failures can be traced in the API's logs. Defaults to true.",
+				Optional: true,
+			},
+			"completed_encoding": schema.StringAttribute{
+				Description: "How the API represents completion: \"bool\" for a boolean completed field, or \"status\" for a status field of \"done\"/\"open\". Defaults to \"bool\".",
+				Optional:    true,
+			},
+			"on_missing_field": schema.StringAttribute{
+				Description: "What refreshing a todo does when the API stops returning a field the provider models, such as during a server schema migration: " +
+					"\"keep\" keeps the value from state, \"warn\" keeps it and reports a warning, and \"clear\" stores the empty value. Defaults to \"clear\".",
+				Optional: true,
+			},
+			"completed_authority": schema.StringAttribute{
+				Description: "Who owns the completed flag of todos: \"terraform\" plans changes back to the configured value, " +
+					"while \"external\" leaves it to another system, so completed cannot be set and changes made outside Terraform never cause a diff. " +
+					"Defaults to \"terraform\".",
+				Optional: true,
+			},
+			"lenient_decode": schema.BoolAttribute{
+				Description: "Accept a completed flag sent as the string \"true\"/\"false\" or \"1\"/\"0\", or the number 1/0, instead of a JSON boolean. " +
+					"For backends that encode it loosely. Defaults to false, which rejects such responses.",
+				Optional: true,
+			},
+			"confirm_not_found": schema.BoolAttribute{
+				Description: "Re-check a todo that is reported as not found once, after a short delay, before removing it from state. Guards against transient 404s. Defaults to false.",
+				Optional:    true,
+			},
+			"dial_timeout": schema.StringAttribute{
+				Description: "Maximum time to establish a TCP connection to the API (e.g. \"5s\"). Defaults to 30s.",
+				Optional:    true,
+			},
+			"tls_handshake_timeout": schema.StringAttribute{
+				Description: "Maximum time to complete the TLS handshake with the API (e.g. \"5s\"). Defaults to 10s.",
+				Optional:    true,
+			},
+			"per_request_timeout": schema.StringAttribute{
+				Description: "Deadline for a single API request attempt (e.g. \"10s\"). Each retry gets a fresh deadline, while Terraform operation timeouts still bound the whole call. Disabled when unset or zero.",
+				Optional:    true,
+			},
+			"retryable_error_messages": schema.ListAttribute{
+				Description: "Treat a 2xx response whose JSON body has an \"error\" field as a failure, retrying idempotent requests when the error contains one of these messages (case-insensitive). For backends that signal failure in the body. Detection is disabled when unset.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"follow_async": schema.BoolAttribute{
+				Description: "When a create or update returns 202 Accepted with a Location header, poll that status URL until the operation finishes and then read the resulting todo. Defaults to false.",
+				Optional:    true,
+			},
+			"prefer_minimal_response": schema.BoolAttribute{
+				Description: "Send Prefer: return=minimal on creates and updates so the API may answer without the todo in the body. " +
+					"The todo is then read back with a GET, so state is still fully populated. Defaults to false.",
+				Optional: true,
+			},
+			"description_prefix": schema.StringAttribute{
+				Description: "Text prepended to every description the provider writes, e.g. \"[managed by terraform] \". It is removed again when reading, so it never shows up as a diff. " +
+					"Only configured descriptions get it; a todo created without one keeps the server's default description as is.",
+				Optional: true,
+			},
+			"description_suffix": schema.StringAttribute{
+				Description: "Text appended to every description the provider writes. It is removed again when reading, so it never shows up as a diff. " +
+					"Only configured descriptions get it; a todo created without one keeps the server's default description as is.",
+				Optional: true,
+			},
+			"resilience_profile": schema.StringAttribute{
+				Description: "Preset for retry behaviour: \"aggressive\", \"balanced\" or \"patient\". Sets the retry count, backoff delays and total retry budget; " +
+					"max_retries, retry_base_delay, retry_max_delay and retry_budget override individual values. When unset, requests are retried 3 times with 500ms to 30s backoff and no budget.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times a request that fails with a connection error, 429 or transient 5xx response is retried. " +
+					"Connection errors and 5xx responses are only retried for idempotent requests: GET, PUT and DELETE, and creates that carry an Idempotency-Key. " +
+					"Requests of any method, including logins, are retried when the server answers 429 or a temporary DNS failure stops them before they are sent. " +
+					"0 disables retries. Defaults to 3. Overrides resilience_profile.",
+				Optional: true,
+			},
+			"retry_base_delay": schema.StringAttribute{
+				Description: "Backoff delay before the first retry, doubling for each later retry (e.g. \"500ms\"). Overrides resilience_profile.",
+				Optional:    true,
+			},
+			"retry_max_delay": schema.StringAttribute{
+				Description: "Upper bound on the backoff delay between retries (e.g. \"30s\"). Overrides resilience_profile.",
+				Optional:    true,
+			},
+			"retry_budget": schema.StringAttribute{
+				Description: "Maximum total time a request may spend across all attempts and waits (e.g. \"2m\"). Overrides resilience_profile.",
+				Optional:    true,
+			},
+			"sensitive_fields": schema.ListAttribute{
+				Description: "JSON field names, such as \"title\" or \"description\", whose values are masked in request and response bodies written to TF_LOG trace output. " +
+					"password, token, access_token and refresh_token are always masked.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"workspace_id": schema.StringAttribute{
+				Description: "Identifies this configuration. Todos it creates are stamped with a \"managed_by\" metadata entry holding this value, " +
+					"and reading a todo stamped by a different workspace reports a mismatch. The stamp is hidden from the metadata attribute.",
+				Optional: true,
+			},
+			"workspace_mismatch": schema.StringAttribute{
+				Description: "How to report a todo managed by a different workspace_id: \"warn\" or \"error\". Defaults to \"warn\".",
+				Optional:    true,
+			},
+			"batch_updates": schema.BoolAttribute{
+				Description: "Coalesce todo updates made in parallel during an apply into a single request to the batch endpoint. " +
+					"Each update waits up to batch_window for others to join. If the batch request fails as a whole, every update in it is reported as failed even if the server applied some; the next refresh reconciles state. " +
+					"Falls back to individual updates when the API has no batch endpoint. Defaults to false.",
+				Optional: true,
+			},
+			"batch_window": schema.StringAttribute{
+				Description: "How long an update waits for others to batch with when batch_updates is enabled (e.g. \"100ms\"). Defaults to 50ms.",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once across all resources, so high Terraform parallelism doesn't open hundreds of connections. " +
+					"Other requests wait for a free slot. Unlimited when unset.",
+				Optional: true,
+			},
+			"enable_read_cache": schema.BoolAttribute{
+				Description: "Cache todos in memory for a few seconds so repeated reads of the same todo during one run are served locally. Stale entries are revalidated by ETag and writes invalidate them. Defaults to false.",
+				Optional:    true,
+			},
+			"sparse_reads": schema.BoolAttribute{
+				Description: "Ask the API for only the fields the todo resource stores when refreshing it, using the fields query parameter, to shrink responses on large refreshes. " +
+					"APIs without sparse fieldsets ignore the parameter. Refreshes then bypass enable_read_cache. Defaults to false.",
+				Optional: true,
+			},
+			"tolerate_read_errors": schema.BoolAttribute{
+				Description: "When refreshing a todo fails with a 5xx response or a network error, keep its last known state and report a warning instead of failing the plan. " +
+					"A todo that is not found is still removed from state. The kept state may be out of date, so this defaults to false.",
+				Optional: true,
+			},
+			"conditional_delete": schema.BoolAttribute{
+				Description: "Delete a todo only if it is unchanged since Terraform last read it, by sending its ETag in If-Match. " +
+					"Has no effect on API versions that do not send ETags. Defaults to false.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -74,10 +342,16 @@ func (p *apibasicsProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Default values from environment variables
-	endpoint := os.Getenv("APIBASICS_ENDPOINT")
-	email := os.Getenv("APIBASICS_EMAIL")
-	password := os.Getenv("APIBASICS_PASSWORD")
+	// Default values from environment variables, unless they are disabled
+	envFallback := !config.DisableEnvFallback.ValueBool()
+	var endpoint, email, password, signingSecret, apiKey string
+	if envFallback {
+		endpoint = os.Getenv("APIBASICS_ENDPOINT")
+		email = os.Getenv("APIBASICS_EMAIL")
+		password = os.Getenv("APIBASICS_PASSWORD")
+		signingSecret = os.Getenv("APIBASICS_SIGNING_SECRET")
+		apiKey = os.Getenv("APIBASICS_API_KEY")
+	}
 
 	// Override with explicit configuration
 	if !config.Endpoint.IsNull() {
@@ -89,25 +363,238 @@ func (p *apibasicsProvider) Configure(ctx context.Context, req provider.Configur
 	if !config.Password.IsNull() {
 		password = config.Password.ValueString()
 	}
+	if !config.SigningSecret.IsNull() {
+		signingSecret = config.SigningSecret.ValueString()
+	}
+	if !config.APIKey.IsNull() {
+		apiKey = config.APIKey.ValueString()
+	}
+
+	// Credentials from an external command replace any from the environment
+	token := ""
+	if !config.CredentialsCommand.IsNull() {
+		if !config.Email.IsNull() || !config.Password.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_command"),
+				"Conflicting Credentials Configuration",
+				"credentials_command cannot be combined with email or password in the provider configuration.",
+			)
+			return
+		}
+
+		var argv []string
+		resp.Diagnostics.Append(config.CredentialsCommand.ElementsAs(ctx, &argv, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		creds, err := runCredentialsCommand(ctx, argv)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_command"),
+				"Credentials Command Failed",
+				"Could not obtain credentials from credentials_command: "+err.Error(),
+			)
+			return
+		}
+		email, password, token = creds.Email, creds.Password, creds.Token
+	}
 
 	// Validate required fields
 	if endpoint == "" {
 		endpoint = "https://api-basics.sharted.workers.dev"
 	}
 
-	if email == "" {
-		resp.Diagnostics.AddError(
-			"Missing Email Configuration",
-			"The provider requires an email for authentication. "+
-				"Set the email value in the configuration or use the APIBASICS_EMAIL environment variable.",
+	apiKeyMode := "only"
+	if !config.APIKeyMode.IsNull() {
+		apiKeyMode = config.APIKeyMode.ValueString()
+		if apiKeyMode != "only" && apiKeyMode != "with_bearer" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_mode"),
+				"Invalid API Key Mode",
+				fmt.Sprintf("api_key_mode must be \"only\" or \"with_bearer\", got %q.", apiKeyMode),
+			)
+		}
+	}
+
+	// An API key on its own replaces logging in
+	apiKeyOnly := apiKey != "" && apiKeyMode == "only" && token == ""
+	if apiKeyOnly {
+		email, password = "", ""
+	}
+
+	// A token from credentials_command is used as is, without logging in
+	if token == "" && email == "" && !apiKeyOnly {
+		if envFallback {
+			resp.Diagnostics.AddError(
+				"Missing Email Configuration",
+				"The provider requires an email for authentication. "+
+					"Set the email value in the configuration or use the APIBASICS_EMAIL environment variable.",
+			)
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email"),
+				"Missing Email Configuration",
+				"The provider requires an email for authentication. "+
+					"Set the email value in the configuration; APIBASICS_EMAIL is ignored because disable_env_fallback is set.",
+			)
+		}
+	}
+
+	if token == "" && password == "" && !apiKeyOnly {
+		if envFallback {
+			resp.Diagnostics.AddError(
+				"Missing Password Configuration",
+				"The provider requires a password for authentication. "+
+					"Set the password value in the configuration or use the APIBASICS_PASSWORD environment variable.",
+			)
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing Password Configuration",
+				"The provider requires a password for authentication. "+
+					"Set the password value in the configuration; APIBASICS_PASSWORD is ignored because disable_env_fallback is set.",
+			)
+		}
+	}
+
+	tokenPath := client.DefaultTokenPath
+	if !config.TokenPath.IsNull() {
+		tokenPath = config.TokenPath.ValueString()
+		if err := validateURLPath(tokenPath); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_path"),
+				"Invalid Token Path",
+				err.Error(),
+			)
+		}
+	}
+
+	authScheme := ""
+	if !config.AuthScheme.IsNull() {
+		authScheme = config.AuthScheme.ValueString()
+		if authScheme == "" || strings.ContainsAny(authScheme, " \t\r\n") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth_scheme"),
+				"Invalid Auth Scheme",
+				fmt.Sprintf("auth_scheme must be a single word such as \"Bearer\", got %q.", authScheme),
+			)
+		}
+	}
+
+	completedEncoding := client.CompletedEncodingBool
+	if !config.CompletedEncoding.IsNull() {
+		completedEncoding = client.CompletedEncoding(config.CompletedEncoding.ValueString())
+		if completedEncoding != client.CompletedEncodingBool && completedEncoding != client.CompletedEncodingStatus {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("completed_encoding"),
+				"Invalid Completed Encoding",
+				fmt.Sprintf("completed_encoding must be %q or %q, got %q.", client.CompletedEncodingBool, client.CompletedEncodingStatus, completedEncoding),
+			)
+		}
+	}
+
+	requestPriority := ""
+	if !config.RequestPriority.IsNull() {
+		switch v := config.RequestPriority.ValueString(); v {
+		case "normal":
+		case "high", "low":
+			requestPriority = v
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_priority"),
+				"Invalid Request Priority",
+				fmt.Sprintf("request_priority must be one of \"high\", \"normal\" or \"low\", got %q.", v),
+			)
+		}
+	}
+
+	var sensitiveFields []string
+	if !config.SensitiveFields.IsNull() {
+		resp.Diagnostics.Append(config.SensitiveFields.ElementsAs(ctx, &sensitiveFields, false)...)
+	}
+
+	var retryableErrorMessages []string
+	if !config.RetryableErrorMessages.IsNull() {
+		resp.Diagnostics.Append(config.RetryableErrorMessages.ElementsAs(ctx, &retryableErrorMessages, false)...)
+	}
+
+	workspaceMismatch := "warn"
+	if !config.WorkspaceMismatch.IsNull() {
+		workspaceMismatch = config.WorkspaceMismatch.ValueString()
+		if workspaceMismatch != "warn" && workspaceMismatch != "error" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("workspace_mismatch"),
+				"Invalid Workspace Mismatch Setting",
+				fmt.Sprintf("workspace_mismatch must be \"warn\" or \"error\", got %q.", workspaceMismatch),
+			)
+		}
+	}
+
+	onMissingField := missingFieldClear
+	if !config.OnMissingField.IsNull() {
+		onMissingField = missingFieldMode(config.OnMissingField.ValueString())
+		switch onMissingField {
+		case missingFieldKeep, missingFieldWarn, missingFieldClear:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("on_missing_field"),
+				"Invalid Missing Field Setting",
+				fmt.Sprintf("on_missing_field must be %q, %q or %q, got %q.", missingFieldKeep, missingFieldWarn, missingFieldClear, onMissingField),
+			)
+		}
+	}
+
+	completedBy := completedAuthorityTerraform
+	if !config.CompletedAuthority.IsNull() {
+		completedBy = completedAuthority(config.CompletedAuthority.ValueString())
+		switch completedBy {
+		case completedAuthorityTerraform, completedAuthorityExternal:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("completed_authority"),
+				"Invalid Completed Authority",
+				fmt.Sprintf("completed_authority must be %q or %q, got %q.", completedAuthorityTerraform, completedAuthorityExternal, completedBy),
+			)
+		}
+	}
+
+	var profile *resilienceProfile
+	if !config.ResilienceProfile.IsNull() {
+		name := config.ResilienceProfile.ValueString()
+		if preset, ok := resilienceProfiles[name]; ok {
+			profile = &preset
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("resilience_profile"),
+				"Invalid Resilience Profile",
+				fmt.Sprintf("resilience_profile must be one of %s, got %q.", strings.Join(resilienceProfileNames(), ", "), name),
+			)
+		}
+	}
+
+	slowRequestThreshold := parseDuration(config.SlowRequestThreshold, "slow_request_threshold", &resp.Diagnostics)
+	perRequestTimeout := parseDuration(config.PerRequestTimeout, "per_request_timeout", &resp.Diagnostics)
+	retryBaseDelay := parseDuration(config.RetryBaseDelay, "retry_base_delay", &resp.Diagnostics)
+	retryMaxDelay := parseDuration(config.RetryMaxDelay, "retry_max_delay", &resp.Diagnostics)
+	retryBudget := parseDuration(config.RetryBudget, "retry_budget", &resp.Diagnostics)
+	batchWindow := parseDuration(config.BatchWindow, "batch_window", &resp.Diagnostics)
+	dialTimeout := parseDuration(config.DialTimeout, "dial_timeout", &resp.Diagnostics)
+	tlsHandshakeTimeout := parseDuration(config.TLSHandshakeTimeout, "tls_handshake_timeout", &resp.Diagnostics)
+
+	if !config.MaxConcurrentRequests.IsNull() && config.MaxConcurrentRequests.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Maximum Concurrent Requests",
+			fmt.Sprintf("max_concurrent_requests must be at least 1, got %d.", config.MaxConcurrentRequests.ValueInt64()),
 		)
 	}
 
-	if password == "" {
-		resp.Diagnostics.AddError(
-			"Missing Password Configuration",
-			"The provider requires a password for authentication. "+
-				"Set the password value in the configuration or use the APIBASICS_PASSWORD environment variable.",
+	if !config.MaxRetries.IsNull() && config.MaxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Maximum Retries",
+			fmt.Sprintf("max_retries must not be negative, got %d.", config.MaxRetries.ValueInt64()),
 		)
 	}
 
@@ -117,25 +604,102 @@ func (p *apibasicsProvider) Configure(ctx context.Context, req provider.Configur
 
 	// Create API client
 	apiClient := client.NewClient(endpoint, email, password)
+	apiClient.SetTransportTimeouts(dialTimeout, tlsHandshakeTimeout)
+	if profile != nil {
+		profile.apply(apiClient)
+	}
+	if !config.MaxRetries.IsNull() {
+		apiClient.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryBaseDelay.IsNull() {
+		apiClient.RetryBaseDelay = retryBaseDelay
+	}
+	if !config.RetryMaxDelay.IsNull() {
+		apiClient.RetryMaxDelay = retryMaxDelay
+	}
+	if !config.RetryBudget.IsNull() {
+		apiClient.RetryBudget = retryBudget
+	}
+	apiClient.UserAgent = "terraform-provider-apibasics/" + p.version
+	apiClient.TokenPath = tokenPath
+	apiClient.AuthScheme = authScheme
+	apiClient.SlowRequestThreshold = slowRequestThreshold
+	apiClient.PerRequestTimeout = perRequestTimeout
+	apiClient.ConfirmNotFound = config.ConfirmNotFound.ValueBool()
+	apiClient.CompletedEncoding = completedEncoding
+	apiClient.LenientDecode = config.LenientDecode.ValueBool()
+	apiClient.RequestPriority = requestPriority
+	if !config.SendRequestIDs.IsNull() {
+		apiClient.SendRequestIDs = config.SendRequestIDs.ValueBool()
+	}
+	apiClient.RetryableErrorMessages = retryableErrorMessages
+	apiClient.SensitiveFields = sensitiveFields
+	apiClient.SigningSecret = signingSecret
+	apiClient.APIKey = apiKey
+	apiClient.FollowAsync = config.FollowAsync.ValueBool()
+	apiClient.PreferMinimal = config.PreferMinimalResponse.ValueBool()
+	if config.BatchUpdates.ValueBool() {
+		apiClient.EnableUpdateBatching(batchWindow)
+	}
+	if config.EnableReadCache.ValueBool() {
+		apiClient.EnableReadCache(client.DefaultReadCacheTTL)
+	}
+	apiClient.LimitConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64()))
 
-	// Authenticate with the API
-	if err := apiClient.Authenticate(); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Authenticate with API",
-			"An unexpected error occurred when authenticating with the API. "+
-				"Error: "+err.Error(),
-		)
-		return
+	// Authenticate with the API, unless a token or API key was supplied
+	switch {
+	case token != "":
+		apiClient.AccessToken = token
+	case apiKeyOnly:
+		// Every request carries the key instead
+	default:
+		if err := apiClient.Authenticate(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Authenticate with API",
+				"An unexpected error occurred when authenticating with the API. "+
+					"Error: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// Make the API client available to resources and data sources
-	resp.DataSourceData = apiClient
-	resp.ResourceData = apiClient
+	data := &providerData{
+		client:      apiClient,
+		diagnostics: p.diagnosticMapper,
+		descriptionAffixes: descriptionAffixes{
+			prefix: config.DescriptionPrefix.ValueString(),
+			suffix: config.DescriptionSuffix.ValueString(),
+		},
+		workspace: workspaceOwnership{
+			id:              config.WorkspaceID.ValueString(),
+			mismatchIsError: workspaceMismatch == "error",
+		},
+		conditionalDelete:  config.ConditionalDelete.ValueBool(),
+		tolerateReadErrors: config.TolerateReadErrors.ValueBool(),
+		sparseReads:        config.SparseReads.ValueBool(),
+		onMissingField:     onMissingField,
+		completedAuthority: completedBy,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *apibasicsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewHealthDataSource,
+		NewImportableTodosDataSource,
+		NewTodoDataSource,
+		NewTodoChildrenDataSource,
+		NewTodoCompletionDataSource,
+		NewTodoExportDataSource,
+		NewTodoHistoryDataSource,
+		NewTodosDataSource,
+		NewTodosBackupDataSource,
+		NewTodosBulkPlanDataSource,
+		NewUserDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -144,3 +708,57 @@ func (p *apibasicsProvider) Resources(_ context.Context) []func() resource.Resou
 		NewTodoResource,
 	}
 }
+
+// parseDuration converts an optional duration string attribute, recording an
+// attribute error on diags if it is malformed or negative.
+func parseDuration(value types.String, attribute string, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return 0
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Duration",
+			fmt.Sprintf("The %s value %q must be a non-negative duration such as \"500ms\" or \"2s\".", attribute, value.ValueString()),
+		)
+		return 0
+	}
+
+	return d
+}
+
+// parseTimestamp parses an optional RFC 3339 timestamp attribute, adding an
+// attribute error when it is malformed. Null or unknown values return the
+// zero time.
+func parseTimestamp(value types.String, attribute string, diags *diag.Diagnostics) time.Time {
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Timestamp",
+			fmt.Sprintf("The %s value %q must be an RFC 3339 timestamp such as \"2024-01-02T15:04:05Z\".", attribute, value.ValueString()),
+		)
+		return time.Time{}
+	}
+
+	return t
+}
+
+// validateURLPath checks that p is an absolute URL path with no scheme,
+// host, query or fragment.
+func validateURLPath(p string) error {
+	u, err := url.Parse(p)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL path: %w", p, err)
+	}
+	if u.Scheme != "" || u.Host != "" || u.RawQuery != "" || u.Fragment != "" || !strings.HasPrefix(u.Path, "/") {
+		return fmt.Errorf("%q must be a path starting with \"/\", without a scheme, host, query or fragment", p)
+	}
+	return nil
+}
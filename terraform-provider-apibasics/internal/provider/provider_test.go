@@ -0,0 +1,16 @@
+package provider
+
+import "testing"
+
+func TestValidateURLPath(t *testing.T) {
+	for _, p := range []string{"/token", "/auth/login", "/oauth/token"} {
+		if err := validateURLPath(p); err != nil {
+			t.Errorf("validateURLPath(%q) error = %v, want nil", p, err)
+		}
+	}
+	for _, p := range []string{"", "token", "https://auth.example.com/token", "//auth.example.com/token", "/token?grant=password", "/token#top", "/%zz"} {
+		if err := validateURLPath(p); err == nil {
+			t.Errorf("validateURLPath(%q) = nil, want an error", p)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"sort"
+	"time"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+)
+
+// resilienceProfile is a named preset of retry settings.
+type resilienceProfile struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	budget     time.Duration
+}
+
+// resilienceProfiles are the presets accepted by resilience_profile. Keep
+// the README table in sync when changing these values.
+var resilienceProfiles = map[string]resilienceProfile{
+	// Retry quickly and give up fast, for interactive runs on good networks
+	"aggressive": {maxRetries: 5, baseDelay: 200 * time.Millisecond, maxDelay: 2 * time.Second, budget: 15 * time.Second},
+
+	// The client defaults
+	"balanced": {maxRetries: client.DefaultMaxRetries, baseDelay: client.DefaultRetryBaseDelay, maxDelay: client.DefaultRetryMaxDelay, budget: 2 * time.Minute},
+
+	// Keep trying for a long time, for flaky VPN or mobile links
+	"patient": {maxRetries: 10, baseDelay: time.Second, maxDelay: time.Minute, budget: 10 * time.Minute},
+}
+
+// resilienceProfileNames returns the valid profile names in a stable order.
+func resilienceProfileNames() []string {
+	names := make([]string, 0, len(resilienceProfiles))
+	for name := range resilienceProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// apply sets the client's retry parameters from the profile.
+func (p resilienceProfile) apply(c *client.Client) {
+	c.MaxRetries = p.maxRetries
+	c.RetryBaseDelay = p.baseDelay
+	c.RetryMaxDelay = p.maxDelay
+	c.RetryBudget = p.budget
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sparseServer serves the stored todo, limited to the fields query when one
+// is sent, and counts the response bytes in sent
+func sparseServer(t *testing.T, sent *atomic.Int64) http.HandlerFunc {
+	stored := map[string]any{
+		"id":          testTodoID,
+		"title":       "Write tests",
+		"description": "",
+		"completed":   true,
+		"completedAt": "2024-05-01T12:00:00Z",
+		"userId":      "2d7c9e1a-3f4a-4b6c-8d8e-9f1a2b3c4d5e",
+		"createdAt":   "2024-05-01T10:00:00Z",
+		"updatedAt":   "2024-05-01T12:00:00Z",
+		"metadata":    map[string]string{"team": "core"},
+		"version":     3,
+		"attachments": strings.Repeat("x", 4096),
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := stored
+		if fields := r.URL.Query().Get("fields"); fields != "" {
+			body = map[string]any{}
+			for _, field := range strings.Split(fields, ",") {
+				if v, ok := stored[field]; ok {
+					body[field] = v
+				}
+			}
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Errorf("encoding todo: %v", err)
+		}
+		sent.Add(int64(len(data)))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
+
+func TestSparseReadKeepsStateAndShrinksPayload(t *testing.T) {
+	read := func(fields []string) (todoResourceModel, int64) {
+		var sent atomic.Int64
+		r := newTestTodoResource(t, sparseServer(t, &sent))
+
+		todo, err := r.client.GetTodoFields(context.Background(), testTodoID, fields)
+		if err != nil {
+			t.Fatalf("GetTodoFields(%v) error = %v", fields, err)
+		}
+		model := storedTodo()
+		if diags := model.setFromTodo(context.Background(), todo, r.descriptionAffixes); diags.HasError() {
+			t.Fatalf("setFromTodo: %v", diags)
+		}
+		// The raw response differs by design when fewer fields are sent
+		model.RawJSON = types.StringNull()
+		return model, sent.Load()
+	}
+
+	full, fullBytes := read(nil)
+	sparse, sparseBytes := read(todoStateFields)
+
+	r := newTestTodoResource(t, nil)
+	if got, want := todoObject(t, r, sparse), todoObject(t, r, full); !got.Equal(want) {
+		t.Errorf("sparse read state = %v, want %v", got, want)
+	}
+	if sparseBytes >= fullBytes {
+		t.Errorf("sparse read received %d bytes, want fewer than the full read's %d", sparseBytes, fullBytes)
+	}
+}
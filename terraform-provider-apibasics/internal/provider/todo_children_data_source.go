@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoChildrenDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoChildrenDataSource{}
+)
+
+// NewTodoChildrenDataSource is a helper function to simplify the provider implementation.
+func NewTodoChildrenDataSource() datasource.DataSource {
+	return &todoChildrenDataSource{}
+}
+
+// todoChildrenDataSource is the data source implementation.
+type todoChildrenDataSource struct {
+	client *client.Client
+}
+
+// todoChildrenDataSourceModel maps the data source schema data.
+type todoChildrenDataSourceModel struct {
+	ParentID types.String        `tfsdk:"parent_id"`
+	Todos    []todoListItemModel `tfsdk:"todos"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoChildrenDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo_children"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoChildrenDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the direct subtasks of a todo.",
+		Attributes: map[string]schema.Attribute{
+			"parent_id": schema.StringAttribute{
+				Description: "UUID of the parent todo.",
+				Required:    true,
+			},
+			"todos": schema.ListNestedAttribute{
+				Description: "The child todos.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: todoListItemAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoChildrenDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoChildrenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoChildrenDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	children, err := d.client.ListTodoChildren(ctx, state.ParentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Todo Children",
+			"Could not list children of todo ID "+state.ParentID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Todos = make([]todoListItemModel, 0, len(children))
+	for _, todo := range children {
+		state.Todos = append(state.Todos, newTodoListItem(todo))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Listed todo children", map[string]any{"parent_id": state.ParentID.ValueString(), "count": len(children)})
+}
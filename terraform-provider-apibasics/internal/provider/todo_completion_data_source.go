@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultCompletionTimeout bounds how long apibasics_todo_completion waits
+// when no timeout is configured
+const defaultCompletionTimeout = 10 * time.Minute
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoCompletionDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoCompletionDataSource{}
+)
+
+// NewTodoCompletionDataSource is a helper function to simplify the provider implementation.
+func NewTodoCompletionDataSource() datasource.DataSource {
+	return &todoCompletionDataSource{}
+}
+
+// todoCompletionDataSource is the data source implementation.
+type todoCompletionDataSource struct {
+	client *client.Client
+}
+
+// todoCompletionDataSourceModel maps the data source schema data.
+type todoCompletionDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Timeout   types.String `tfsdk:"timeout"`
+	Completed types.Bool   `tfsdk:"completed"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoCompletionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo_completion"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoCompletionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Waits until a todo is completed, for example by another system, before dependent resources proceed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the todo to wait for.",
+				Required:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: fmt.Sprintf("How long to wait before failing (e.g. \"30m\"). Defaults to %s.", defaultCompletionTimeout),
+				Optional:    true,
+			},
+			"completed": schema.BoolAttribute{
+				Description: "Always true once the read succeeds.",
+				Computed:    true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Timestamp when the todo was last updated, normally when it was completed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoCompletionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoCompletionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoCompletionDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := parseDuration(state.Timeout, "timeout", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if timeout == 0 {
+		timeout = defaultCompletionTimeout
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	todo, err := d.client.WaitForCompletion(waitCtx, state.ID.ValueString())
+	if err != nil {
+		detail := "Could not wait for todo ID " + state.ID.ValueString() + " to be completed: " + err.Error()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			detail = fmt.Sprintf("Todo ID %s was not completed within %s.", state.ID.ValueString(), timeout)
+		}
+		resp.Diagnostics.AddError("Error Waiting for Todo Completion", detail)
+		return
+	}
+
+	state.Completed = types.BoolValue(todo.Completed)
+	state.UpdatedAt = types.StringValue(todo.UpdatedAt)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Todo completed", map[string]any{"id": todo.ID})
+}
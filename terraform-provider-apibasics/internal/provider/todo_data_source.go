@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoDataSource{}
+)
+
+// NewTodoDataSource is a helper function to simplify the provider implementation.
+func NewTodoDataSource() datasource.DataSource {
+	return &todoDataSource{}
+}
+
+// todoDataSource is the data source implementation.
+type todoDataSource struct {
+	client *client.Client
+}
+
+// todoDataSourceModel maps the data source schema data.
+type todoDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+	CompletedAt types.String `tfsdk:"completed_at"`
+	UserID      types.String `tfsdk:"user_id"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+	Metadata    types.Map    `tfsdk:"metadata"`
+	ParentID    types.String `tfsdk:"parent_id"`
+	ModifiedBy  types.String `tfsdk:"modified_by"`
+	RawJSON     types.String `tfsdk:"raw_json"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a single todo by id, without managing it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the todo.",
+				Required:    true,
+			},
+			"title": schema.StringAttribute{
+				Description: "Title of the todo.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the todo.",
+				Computed:    true,
+			},
+			"completed": schema.BoolAttribute{
+				Description: "Whether the todo is completed.",
+				Computed:    true,
+			},
+			"completed_at": schema.StringAttribute{
+				Description: "Timestamp when the todo was completed, when the API records it.",
+				Computed:    true,
+			},
+			"user_id": schema.StringAttribute{
+				Description: "UUID of the user who owns this todo.",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Timestamp when the todo was created.",
+				Computed:    true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "Timestamp when the todo was last updated.",
+				Computed:    true,
+			},
+			"metadata": schema.MapAttribute{
+				Description: "Key/value pairs attached to the todo.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "UUID of the parent todo, if this todo is a subtask.",
+				Computed:    true,
+			},
+			"modified_by": schema.StringAttribute{
+				Description: "UUID of the user who last modified the todo, when the API tracks it.",
+				Computed:    true,
+			},
+			"raw_json": schema.StringAttribute{
+				Description: rawJSONDescription,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	todo, err := d.client.GetTodo(ctx, state.ID.ValueString())
+	if errors.Is(err, client.ErrInvalidID) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Todo ID",
+			err.Error(),
+		)
+		return
+	}
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Todo Not Found",
+			"No todo with ID "+state.ID.ValueString()+" exists, or it is not visible to the provider's credentials.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todo",
+			"Could not read todo ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Title = types.StringValue(todo.Title)
+	state.Description = types.StringValue(todo.Description)
+	state.Completed = types.BoolValue(todo.Completed)
+	state.CompletedAt = types.StringNull()
+	if todo.CompletedAt != "" {
+		state.CompletedAt = types.StringValue(todo.CompletedAt)
+	}
+	state.UserID = types.StringValue(todo.UserID)
+	state.CreatedAt = types.StringValue(todo.CreatedAt)
+	state.UpdatedAt = types.StringValue(todo.UpdatedAt)
+	state.ParentID = types.StringNull()
+	if todo.ParentID != "" {
+		state.ParentID = types.StringValue(todo.ParentID)
+	}
+	state.ModifiedBy = types.StringNull()
+	if todo.ModifiedBy != "" {
+		state.ModifiedBy = types.StringValue(todo.ModifiedBy)
+	}
+	state.RawJSON = rawJSONValue(todo)
+
+	// The API omits empty metadata; report it as an empty map so
+	// references such as lookup() work either way
+	metadata := todo.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	state.Metadata, diags = types.MapValueFrom(ctx, types.StringType, metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read todo", map[string]any{"id": todo.ID})
+}
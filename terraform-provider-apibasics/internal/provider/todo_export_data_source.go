@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoExportDataSource{}
+)
+
+// NewTodoExportDataSource is a helper function to simplify the provider implementation.
+func NewTodoExportDataSource() datasource.DataSource {
+	return &todoExportDataSource{}
+}
+
+// todoExportDataSource is the data source implementation.
+type todoExportDataSource struct {
+	client *client.Client
+}
+
+// todoExportDataSourceModel maps the data source schema data.
+type todoExportDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Format            types.String `tfsdk:"format"`
+	StripServerFields types.Bool   `tfsdk:"strip_server_fields"`
+	Content           types.String `tfsdk:"content"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Serialises a todo to JSON or YAML for backups and migrations.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the todo to export.",
+				Required:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "Output format: \"json\" or \"yaml\". Defaults to \"json\".",
+				Optional:    true,
+			},
+			"strip_server_fields": schema.BoolAttribute{
+				Description: "Leave out the id, owner and timestamps so the export can be used to recreate the todo. Defaults to false.",
+				Optional:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The serialised todo.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoExportDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	format := client.ExportFormatJSON
+	if !state.Format.IsNull() {
+		format = state.Format.ValueString()
+	}
+	if format != client.ExportFormatJSON && format != client.ExportFormatYAML {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("format"),
+			"Invalid Export Format",
+			fmt.Sprintf("format must be %q or %q, got %q.", client.ExportFormatJSON, client.ExportFormatYAML, format),
+		)
+		return
+	}
+
+	content, err := d.client.ExportTodo(ctx, state.ID.ValueString(), format, state.StripServerFields.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Exporting Todo",
+			"Could not export todo ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Content = types.StringValue(string(content))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Exported todo", map[string]any{"id": state.ID.ValueString(), "format": format})
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoHistoryDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoHistoryDataSource{}
+)
+
+// NewTodoHistoryDataSource is a helper function to simplify the provider implementation.
+func NewTodoHistoryDataSource() datasource.DataSource {
+	return &todoHistoryDataSource{}
+}
+
+// todoHistoryDataSource is the data source implementation.
+type todoHistoryDataSource struct {
+	client *client.Client
+}
+
+// todoHistoryDataSourceModel maps the data source schema data.
+type todoHistoryDataSourceModel struct {
+	ID        types.String        `tfsdk:"id"`
+	Revisions []todoRevisionModel `tfsdk:"revisions"`
+}
+
+// todoRevisionModel maps a single revision in the history.
+type todoRevisionModel struct {
+	Revision      types.Int64  `tfsdk:"revision"`
+	Timestamp     types.String `tfsdk:"timestamp"`
+	ModifiedBy    types.String `tfsdk:"modified_by"`
+	ChangedFields types.List   `tfsdk:"changed_fields"`
+}
+
+// Metadata returns the data source type name.
+func (d *todoHistoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todo_history"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoHistoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the recorded changes to a todo, for auditing. Requires an API that keeps todo history.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "UUID of the todo.",
+				Required:    true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				Description: "The changes to the todo, in the order the API reports them.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"revision": schema.Int64Attribute{
+							Description: "Sequence number of the change, when the API reports one.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "When the change was made.",
+							Computed:    true,
+						},
+						"modified_by": schema.StringAttribute{
+							Description: "UUID of the user who made the change, when the API tracks it.",
+							Computed:    true,
+						},
+						"changed_fields": schema.ListAttribute{
+							Description: "Names of the fields the change touched.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoHistoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todoHistoryDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revisions, err := d.client.GetTodoHistory(ctx, state.ID.ValueString())
+	if errors.Is(err, client.ErrHistoryUnsupported) {
+		resp.Diagnostics.AddError(
+			"Todo History Not Supported",
+			"The API does not keep todo history, so the history of todo ID "+state.ID.ValueString()+" cannot be read.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todo History",
+			"Could not read history of todo ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Revisions = make([]todoRevisionModel, 0, len(revisions))
+	for _, revision := range revisions {
+		if revision.ChangedFields == nil {
+			revision.ChangedFields = []string{}
+		}
+		changedFields, diags := types.ListValueFrom(ctx, types.StringType, revision.ChangedFields)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		item := todoRevisionModel{
+			Revision:      types.Int64Null(),
+			Timestamp:     types.StringValue(revision.Timestamp),
+			ModifiedBy:    types.StringNull(),
+			ChangedFields: changedFields,
+		}
+		if revision.Revision != 0 {
+			item.Revision = types.Int64Value(int64(revision.Revision))
+		}
+		if revision.ModifiedBy != "" {
+			item.ModifiedBy = types.StringValue(revision.ModifiedBy)
+		}
+		state.Revisions = append(state.Revisions, item)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Read todo history", map[string]any{"id": state.ID.ValueString(), "count": len(revisions)})
+}
@@ -2,15 +2,20 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,19 +23,57 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &todoResource{}
-	_ resource.ResourceWithConfigure   = &todoResource{}
-	_ resource.ResourceWithImportState = &todoResource{}
+	_ resource.Resource                   = &todoResource{}
+	_ resource.ResourceWithConfigure      = &todoResource{}
+	_ resource.ResourceWithImportState    = &todoResource{}
+	_ resource.ResourceWithValidateConfig = &todoResource{}
+	_ resource.ResourceWithModifyPlan     = &todoResource{}
 )
 
 // NewTodoResource is a helper function to simplify the provider implementation.
 func NewTodoResource() resource.Resource {
-	return &todoResource{}
+	return &todoResource{diagnostics: defaultDiagnosticMapper{}}
 }
 
 // todoResource is the resource implementation.
 type todoResource struct {
-	client *client.Client
+	client      *client.Client
+	diagnostics DiagnosticMapper
+
+	// descriptionAffixes are applied to descriptions written to the API
+	descriptionAffixes descriptionAffixes
+
+	// workspace stamps created todos and checks ownership on read
+	workspace workspaceOwnership
+
+	// conditionalDelete sends the last seen ETag with deletes
+	conditionalDelete bool
+
+	// tolerateReadErrors keeps the prior state when Read hits an outage
+	tolerateReadErrors bool
+
+	// sparseReads limits Read to the fields in todoStateFields
+	sparseReads bool
+
+	// onMissingField decides what Read does with fields the API omits
+	onMissingField missingFieldMode
+
+	// completedAuthority decides whether completed changes are planned
+	completedAuthority completedAuthority
+
+	// overrideClients caches authenticated clients for endpoint_override,
+	// keyed by endpoint
+	overrideMu      sync.Mutex
+	overrideClients map[string]*client.Client
+}
+
+// todoStateFields are the API fields setFromTodo reads, which are all a
+// sparse read requests, plus the version kept in private state for
+// conditional updates.
+var todoStateFields = []string{
+	"title", "description", "completed", "completedAt", "userId",
+	"createdAt", "updatedAt", "metadata", "parentId", "modifiedBy",
+	"version",
 }
 
 // todoResourceModel maps the resource schema data.
@@ -39,9 +82,79 @@ type todoResourceModel struct {
 	Title       types.String `tfsdk:"title"`
 	Description types.String `tfsdk:"description"`
 	Completed   types.Bool   `tfsdk:"completed"`
+	CompletedAt types.String `tfsdk:"completed_at"`
 	UserID      types.String `tfsdk:"user_id"`
 	CreatedAt   types.String `tfsdk:"created_at"`
 	UpdatedAt   types.String `tfsdk:"updated_at"`
+	Metadata    types.Map    `tfsdk:"metadata"`
+	ParentID    types.String `tfsdk:"parent_id"`
+	ModifiedBy  types.String `tfsdk:"modified_by"`
+	RawJSON     types.String `tfsdk:"raw_json"`
+
+	EndpointOverride types.String `tfsdk:"endpoint_override"`
+}
+
+// setFromTodo copies the API representation of a todo into the model,
+// removing the provider's description affixes.
+func (m *todoResourceModel) setFromTodo(ctx context.Context, todo *client.Todo, affixes descriptionAffixes) diag.Diagnostics {
+	m.ID = types.StringValue(todo.ID)
+	m.Title = types.StringValue(todo.Title)
+	m.Description = types.StringValue(affixes.strip(todo.Description))
+	m.Completed = types.BoolValue(todo.Completed)
+
+	// Servers that don't record completion times never send completedAt,
+	// so a configured value is kept while the todo stays completed
+	switch {
+	case todo.CompletedAt != "":
+		m.CompletedAt = types.StringValue(todo.CompletedAt)
+	case !todo.Completed || m.CompletedAt.IsUnknown():
+		m.CompletedAt = types.StringNull()
+	}
+	m.UserID = types.StringValue(todo.UserID)
+	m.CreatedAt = types.StringValue(todo.CreatedAt)
+	m.UpdatedAt = types.StringValue(todo.UpdatedAt)
+
+	m.ParentID = types.StringNull()
+	if todo.ParentID != "" {
+		m.ParentID = types.StringValue(todo.ParentID)
+	}
+
+	// Older backends don't track who modified a todo
+	m.ModifiedBy = types.StringNull()
+	if todo.ModifiedBy != "" {
+		m.ModifiedBy = types.StringValue(todo.ModifiedBy)
+	}
+
+	m.RawJSON = rawJSONValue(todo)
+
+	// The API omits empty metadata, so preserve whether the configuration
+	// used null or an empty map to avoid a perpetual diff between the two
+	switch {
+	case len(todo.Metadata) > 0:
+		metadata, diags := types.MapValueFrom(ctx, types.StringType, todo.Metadata)
+		m.Metadata = metadata
+		return diags
+	case m.Metadata.IsNull() || m.Metadata.IsUnknown():
+		m.Metadata = types.MapNull(types.StringType)
+	default:
+		m.Metadata = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+
+	return nil
+}
+
+// rawJSONDescription documents the raw_json attribute of todos.
+const rawJSONDescription = "The todo exactly as the API returned it, as a JSON string, for fields the provider does not model yet; read it with jsondecode(). " +
+	"It holds every field the server sends, including the workspace_id stamp in metadata, and is not marked sensitive, so it appears in plan output. " +
+	"With sparse_reads only the fields the provider models are included."
+
+// rawJSONValue returns the raw JSON a todo was decoded from, or null if it
+// was not decoded from a response.
+func rawJSONValue(todo *client.Todo) types.String {
+	if len(todo.Raw) == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(string(todo.Raw))
 }
 
 // Metadata returns the resource type name.
@@ -66,16 +179,28 @@ func (r *todoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:    true,
 			},
 			"description": schema.StringAttribute{
-				Description: "Description of the todo.",
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString(""),
+				Description: "Description of the todo. When omitted, the server's default description is used, " +
+					"without the provider's description_prefix and description_suffix.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"completed": schema.BoolAttribute{
-				Description: "Whether the todo is completed.",
-				Optional:    true,
-				Computed:    true,
-				Default:     booldefault.StaticBool(false),
+				Description: "Whether the todo is completed. Defaults to false when the todo is created; " +
+					"once it exists, leaving this unset keeps the server's value.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolDefaultOnCreate(false),
+				},
+			},
+			"completed_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of when the todo was completed. Set it together with completed = true to backdate completion; " +
+					"otherwise the server records the time. Cannot be set when completed is false.",
+				Optional: true,
+				Computed: true,
 			},
 			"user_id": schema.StringAttribute{
 				Description: "UUID of the user who owns this todo.",
@@ -89,26 +214,276 @@ func (r *todoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "Timestamp when the todo was last updated.",
 				Computed:    true,
 			},
+			"modified_by": schema.StringAttribute{
+				Description: "UUID of the user who last modified the todo, when the API tracks it.",
+				Computed:    true,
+			},
+			"raw_json": schema.StringAttribute{
+				Description: rawJSONDescription,
+				Computed:    true,
+			},
+			"endpoint_override": schema.StringAttribute{
+				Description: "Send requests for this todo to a different API endpoint, authenticating with the provider's credentials. " +
+					"Intended for testing against a mock or staged migrations; not for production use. Changing it forces a new todo.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "UUID of the todo this todo is a subtask of. Changing it moves the todo in place; removing it detaches the todo from its parent.",
+				Optional:    true,
+			},
+			"metadata": schema.MapAttribute{
+				Description: "Arbitrary key/value metadata to attach to the todo.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
 
+// ValidateConfig checks attribute combinations the schema cannot express.
+func (r *todoResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config todoResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.CompletedAt.IsNull() || config.CompletedAt.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, config.CompletedAt.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("completed_at"),
+			"Invalid Completion Time",
+			fmt.Sprintf("completed_at must be an RFC 3339 timestamp such as \"2024-01-02T15:04:05Z\", got %q.", config.CompletedAt.ValueString()),
+		)
+	}
+
+	// Unset completed defaults to false on create
+	if !config.Completed.IsUnknown() && !config.Completed.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("completed_at"),
+			"Completion Time Without Completion",
+			"completed_at can only be set when completed = true.",
+		)
+	}
+}
+
+// ModifyPlan applies the provider's completed_authority to the plan and
+// drops updates that would not change the todo.
+func (r *todoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if r.completedAuthority == completedAuthorityExternal {
+		planExternalCompletion(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		planUnchangedTodo(ctx, req, resp)
+	}
+}
+
+// planUnchangedTodo keeps the prior state when the planned todo only
+// differs from it in ways client.TodosEqual ignores, such as a completed_at
+// written with another UTC offset, so no update is planned.
+func planUnchangedTodo(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan, state todoResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// TodosEqual ignores the owner, and the endpoint isn't part of the todo
+	if !plan.UserID.IsUnknown() && !plan.UserID.Equal(state.UserID) {
+		return
+	}
+	if !plan.EndpointOverride.Equal(state.EndpointOverride) {
+		return
+	}
+
+	// Metadata values known only after apply can't be compared yet
+	for _, v := range plan.Metadata.Elements() {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	desired, diags := plan.todo(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	current, diags := state.todo(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !client.TodosEqual(current, desired) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, state)...)
+}
+
+// todo returns the user-settable content of the model as a client.Todo,
+// taking unknown values from prior.
+func (m todoResourceModel) todo(ctx context.Context, prior todoResourceModel) (client.Todo, diag.Diagnostics) {
+	known := func(v, fallback types.String) string {
+		if v.IsUnknown() {
+			return fallback.ValueString()
+		}
+		return v.ValueString()
+	}
+
+	todo := client.Todo{
+		Title:       known(m.Title, prior.Title),
+		Description: known(m.Description, prior.Description),
+		Completed:   m.Completed.ValueBool(),
+		CompletedAt: known(m.CompletedAt, prior.CompletedAt),
+		ParentID:    known(m.ParentID, prior.ParentID),
+	}
+	if m.Completed.IsUnknown() {
+		todo.Completed = prior.Completed.ValueBool()
+	}
+
+	metadata := m.Metadata
+	if metadata.IsUnknown() {
+		metadata = prior.Metadata
+	}
+	if metadata.IsNull() || metadata.IsUnknown() {
+		return todo, nil
+	}
+	diags := metadata.ElementsAs(ctx, &todo.Metadata, false)
+	return todo, diags
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *todoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.diagnostics = data.diagnostics
+	r.descriptionAffixes = data.descriptionAffixes
+	r.workspace = data.workspace
+	r.conditionalDelete = data.conditionalDelete
+	r.tolerateReadErrors = data.tolerateReadErrors
+	r.sparseReads = data.sparseReads
+	r.onMissingField = data.onMissingField
+	r.completedAuthority = data.completedAuthority
+}
+
+// addServerWarnings surfaces any non-fatal warnings from a write response
+// as warning diagnostics.
+func addServerWarnings(diags *diag.Diagnostics, todo *client.Todo) {
+	for _, warning := range todo.Warnings {
+		diags.AddWarning("API Warning", "The API reported a warning for todo "+todo.ID+": "+warning)
+	}
+}
+
+// checkManagedByUnset rejects configured metadata that sets the key reserved
+// for the workspace stamp, reporting whether the metadata is acceptable.
+func (r *todoResource) checkManagedByUnset(metadata map[string]string, diags *diag.Diagnostics) bool {
+	if _, ok := metadata[managedByMetadataKey]; ok && r.workspace.id != "" {
+		diags.AddAttributeError(
+			path.Root("metadata"),
+			"Reserved Metadata Key",
+			fmt.Sprintf("The %q metadata key is set by the provider from workspace_id and cannot be configured.", managedByMetadataKey),
+		)
+		return false
+	}
+	return true
+}
+
+// checkFieldsStored reports metadata or a parent the API dropped from a
+// written todo, reporting whether both were stored. Saving the todo without
+// them would fail with an inconsistent result after apply. A nil metadata
+// or parentID was not written and is not checked.
+func (r *todoResource) checkFieldsStored(metadata map[string]string, parentID *string, todo *client.Todo, diags *diag.Diagnostics) bool {
+	var dropped []string
+	for k, v := range metadata {
+		if got, ok := todo.Metadata[k]; !ok || got != v {
+			dropped = append(dropped, k)
+		}
+	}
+	if len(dropped) > 0 {
+		sort.Strings(dropped)
+		detail := fmt.Sprintf("The API did not store the metadata keys %s sent for todo %s, so it does not support metadata. "+
+			"Remove metadata from the configuration", strings.Join(dropped, ", "), todo.ID)
+		if _, ok := metadata[managedByMetadataKey]; ok && r.workspace.id != "" {
+			detail += ", and unset the provider's workspace_id, which is stored as metadata"
+		}
+		diags.AddAttributeError(path.Root("metadata"), "Metadata Not Supported", detail+".")
+	}
+
+	if parentID != nil && todo.ParentID != *parentID {
+		diags.AddAttributeError(
+			path.Root("parent_id"),
+			"Parent Not Supported",
+			fmt.Sprintf("The API did not store the parent of todo %s, so it does not support subtasks. "+
+				"Remove parent_id from the configuration.", todo.ID),
+		)
+	}
+
+	return !diags.HasError()
+}
+
+// clientFor returns the client to use for a todo: the provider's client, or
+// a separately authenticated one when endpoint_override is set.
+func (r *todoResource) clientFor(ctx context.Context, endpointOverride types.String) (*client.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if endpointOverride.IsNull() || endpointOverride.IsUnknown() || endpointOverride.ValueString() == "" {
+		return r.client, diags
+	}
+
+	endpoint := endpointOverride.ValueString()
+	diags.AddAttributeWarning(
+		path.Root("endpoint_override"),
+		"Endpoint Override In Use",
+		fmt.Sprintf("This todo is managed through %s instead of the provider endpoint. endpoint_override is intended for testing and should not be used in production.", endpoint),
+	)
+
+	r.overrideMu.Lock()
+	defer r.overrideMu.Unlock()
+
+	if apiClient, ok := r.overrideClients[endpoint]; ok {
+		return apiClient, diags
+	}
+
+	// An API key on its own needs no login
+	apiClient := r.client.WithBaseURL(endpoint)
+	if apiClient.APIKey == "" || apiClient.Email != "" {
+		if err := apiClient.Authenticate(ctx); err != nil {
+			diags.AddAttributeError(
+				path.Root("endpoint_override"),
+				"Unable to Authenticate with Endpoint Override",
+				"Could not authenticate with "+endpoint+" using the provider credentials: "+err.Error(),
+			)
+			return nil, diags
+		}
+	}
+
+	if r.overrideClients == nil {
+		r.overrideClients = make(map[string]*client.Client)
+	}
+	r.overrideClients[endpoint] = apiClient
+
+	return apiClient, diags
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -121,13 +496,46 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	apiClient, diags := r.clientFor(ctx, plan.EndpointOverride)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
-	title := plan.Title.ValueString()
-	description := plan.Description.ValueString()
-	completed := plan.Completed.ValueBool()
+	input := client.TodoInput{
+		Title:     plan.Title.ValueStringPointer(),
+		Completed: plan.Completed.ValueBoolPointer(),
+	}
+
+	if !plan.CompletedAt.IsNull() && !plan.CompletedAt.IsUnknown() {
+		input.CompletedAt = plan.CompletedAt.ValueStringPointer()
+	}
 
-	// Create new todo via API
-	todo, err := r.client.CreateTodo(title, description, completed)
+	// Leave description out entirely when unset so the server fills in its default
+	if !plan.Description.IsUnknown() {
+		description := r.descriptionAffixes.apply(plan.Description.ValueString())
+		input.Description = &description
+	}
+
+	if !plan.Metadata.IsNull() {
+		resp.Diagnostics.Append(plan.Metadata.ElementsAs(ctx, &input.Metadata, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !r.checkManagedByUnset(input.Metadata, &resp.Diagnostics) {
+		return
+	}
+	input.Metadata = r.workspace.stamp(input.Metadata)
+
+	if !plan.ParentID.IsNull() {
+		input.ParentID = plan.ParentID.ValueStringPointer()
+	}
+
+	// Create new todo via API. The idempotency key lets a retried create
+	// return the original todo instead of making a duplicate.
+	idempotencyKey, err := client.NewIdempotencyKey()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Todo",
@@ -136,18 +544,40 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	todo, created, err := apiClient.CreateTodoIdempotent(ctx, idempotencyKey, input)
+	if err != nil {
+		resp.Diagnostics.Append(r.diagnostics.MapError(ErrorContext{Operation: "create", Resource: "todo"}, err)...)
+		return
+	}
+	if !created {
+		tflog.Warn(ctx, "Create was retried and the server returned the todo from the first attempt", map[string]any{"id": todo.ID})
+	}
+
+	addServerWarnings(&resp.Diagnostics, todo)
+
+	// Delete a todo that lost its metadata or parent rather than leave it
+	// behind untracked
+	if !r.checkFieldsStored(input.Metadata, input.ParentID, todo, &resp.Diagnostics) {
+		if err := apiClient.DeleteTodo(ctx, todo.ID); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Todo Left Behind",
+				"Could not delete todo "+todo.ID+" after the API dropped some of its fields; delete it manually: "+err.Error(),
+			)
+		}
+		return
+	}
+
 	// Map response body to schema and populate computed attribute values
-	plan.ID = types.StringValue(todo.ID)
-	plan.Title = types.StringValue(todo.Title)
-	plan.Description = types.StringValue(todo.Description)
-	plan.Completed = types.BoolValue(todo.Completed)
-	plan.UserID = types.StringValue(todo.UserID)
-	plan.CreatedAt = types.StringValue(todo.CreatedAt)
-	plan.UpdatedAt = types.StringValue(todo.UpdatedAt)
+	resp.Diagnostics.Append(plan.setFromTodo(ctx, r.workspace.hide(todo), r.descriptionAffixes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, todo.ETag)...)
+	resp.Diagnostics.Append(storeVersion(ctx, resp.Private, todo.Version)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -165,33 +595,52 @@ func (r *todoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	apiClient, diags := r.clientFor(ctx, state.EndpointOverride)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get refreshed todo from API
-	todo, err := r.client.GetTodo(state.ID.ValueString())
+	var fields []string
+	if r.sparseReads {
+		fields = todoStateFields
+	}
+	todo, err := apiClient.GetTodoFields(ctx, state.ID.ValueString(), fields)
 	if err != nil {
 		// If the resource no longer exists, remove it from state
-		if err.Error() == "todo not found" {
+		if errors.Is(err, client.ErrNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
 
-		resp.Diagnostics.AddError(
-			"Error Reading Todo",
-			"Could not read todo ID "+state.ID.ValueString()+": "+err.Error(),
-		)
+		// Leaving resp.State untouched keeps the prior state
+		if r.tolerateReadErrors && client.IsTransient(err) {
+			resp.Diagnostics.AddWarning(
+				"Todo Refresh Skipped",
+				"Could not read todo ID "+state.ID.ValueString()+", so its last known state is kept and may be out of date: "+err.Error(),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(r.diagnostics.MapError(ErrorContext{Operation: "read", Resource: "todo", ID: state.ID.ValueString()}, err)...)
 		return
 	}
 
 	// Overwrite items with refreshed state
-	state.Title = types.StringValue(todo.Title)
-	state.Description = types.StringValue(todo.Description)
-	state.Completed = types.BoolValue(todo.Completed)
-	state.UserID = types.StringValue(todo.UserID)
-	state.CreatedAt = types.StringValue(todo.CreatedAt)
-	state.UpdatedAt = types.StringValue(todo.UpdatedAt)
+	r.workspace.check(todo, &resp.Diagnostics)
+	prior := state
+	resp.Diagnostics.Append(state.setFromTodo(ctx, r.workspace.hide(todo), r.descriptionAffixes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.onMissingField.apply(todo, &prior, &state, &resp.Diagnostics)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, todo.ETag)...)
+	resp.Diagnostics.Append(storeVersion(ctx, resp.Private, todo.Version)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -217,36 +666,128 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// Update existing todo via API
-	title := plan.Title.ValueString()
-	description := plan.Description.ValueString()
-	completed := plan.Completed.ValueBool()
+	apiClient, diags := r.clientFor(ctx, state.EndpointOverride)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the fields that changed so the server doesn't treat
+	// untouched fields as edits
+	var input client.TodoInput
+	if !plan.Title.Equal(state.Title) {
+		input.Title = plan.Title.ValueStringPointer()
+	}
+	if !plan.Description.IsUnknown() && !plan.Description.Equal(state.Description) {
+		description := r.descriptionAffixes.apply(plan.Description.ValueString())
+		input.Description = &description
+	}
+	if !plan.Completed.Equal(state.Completed) {
+		input.Completed = plan.Completed.ValueBoolPointer()
+	}
+	// Sent in the same update as completed so both change together
+	if !plan.CompletedAt.IsNull() && !plan.CompletedAt.IsUnknown() && !plan.CompletedAt.Equal(state.CompletedAt) {
+		input.CompletedAt = plan.CompletedAt.ValueStringPointer()
+	}
+	if !plan.Metadata.Equal(state.Metadata) {
+		// Removing the attribute sends an empty map, which clears the metadata
+		input.Metadata = map[string]string{}
+		resp.Diagnostics.Append(plan.Metadata.ElementsAs(ctx, &input.Metadata, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !r.checkManagedByUnset(input.Metadata, &resp.Diagnostics) {
+			return
+		}
+		// Replacing the metadata must keep the workspace stamp
+		input.Metadata = r.workspace.stamp(input.Metadata)
+	}
 
-	todo, err := r.client.UpdateTodo(
-		state.ID.ValueString(),
-		&title,
-		&description,
-		&completed,
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Todo",
-			"Could not update todo, unexpected error: "+err.Error(),
+	reparent := !plan.ParentID.Equal(state.ParentID)
+	if reparent && plan.ParentID.ValueString() == state.ID.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent_id"),
+			"Invalid Parent",
+			"A todo cannot be its own parent.",
 		)
 		return
 	}
 
+	// Terraform also plans an update when only computed attributes are
+	// unknown. With nothing to write, keep the prior state rather than
+	// sending an empty update that would bump updated_at.
+	fieldsChanged := input.Title != nil || input.Description != nil || input.Completed != nil || input.CompletedAt != nil || input.Metadata != nil
+	if !fieldsChanged && !reparent {
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		tflog.Debug(ctx, "Todo unchanged, skipping update", map[string]any{"id": state.ID.ValueString()})
+		return
+	}
+
+	// Update existing todo via API. Re-parenting is a separate partial
+	// update, so skip the full update when the parent is all that changed.
+	var todo *client.Todo
+	var err error
+	if fieldsChanged {
+		// On APIs that version todos, a todo changed since it was last read is not overwritten
+		version, diags := storedVersion(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if version != 0 {
+			input.Version = &version
+		}
+
+		todo, err = apiClient.UpdateTodo(ctx, state.ID.ValueString(), input)
+		if errors.Is(err, client.ErrTodoChanged) {
+			resp.Diagnostics.AddError(
+				"Todo Changed Since Last Read",
+				"Todo ID "+state.ID.ValueString()+" was modified after Terraform last read it, so it was not updated. "+
+					"Run terraform plan again to review the current state before applying.",
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.Append(r.diagnostics.MapError(ErrorContext{Operation: "update", Resource: "todo", ID: state.ID.ValueString()}, err)...)
+			return
+		}
+		addServerWarnings(&resp.Diagnostics, todo)
+	}
+
+	if reparent {
+		todo, err = apiClient.SetTodoParent(ctx, state.ID.ValueString(), plan.ParentID.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(r.diagnostics.MapError(ErrorContext{Operation: "update", Resource: "todo", ID: state.ID.ValueString()}, err)...)
+			return
+		}
+	}
+
+	var parentID *string
+	if reparent {
+		parentID = plan.ParentID.ValueStringPointer()
+		if parentID == nil {
+			parentID = new(string)
+		}
+	}
+	if !r.checkFieldsStored(input.Metadata, parentID, todo, &resp.Diagnostics) {
+		return
+	}
+
 	// Update resource state with updated values
-	plan.ID = types.StringValue(todo.ID)
-	plan.Title = types.StringValue(todo.Title)
-	plan.Description = types.StringValue(todo.Description)
-	plan.Completed = types.BoolValue(todo.Completed)
-	plan.UserID = types.StringValue(todo.UserID)
-	plan.CreatedAt = types.StringValue(todo.CreatedAt)
-	plan.UpdatedAt = types.StringValue(todo.UpdatedAt)
+	resp.Diagnostics.Append(plan.setFromTodo(ctx, r.workspace.hide(todo), r.descriptionAffixes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, todo.ETag)...)
+	resp.Diagnostics.Append(storeVersion(ctx, resp.Private, todo.Version)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -264,21 +805,52 @@ func (r *todoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Delete existing todo via API
-	err := r.client.DeleteTodo(state.ID.ValueString())
-	if err != nil {
+	apiClient, diags := r.clientFor(ctx, state.EndpointOverride)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing todo via API, refusing to when it changed since it
+	// was last read if conditional deletes are enabled
+	var err error
+	if r.conditionalDelete {
+		etag, diags := storedETag(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err = apiClient.DeleteTodoIfMatch(ctx, state.ID.ValueString(), etag)
+	} else {
+		err = apiClient.DeleteTodo(ctx, state.ID.ValueString())
+	}
+	if errors.Is(err, client.ErrTodoChanged) {
 		resp.Diagnostics.AddError(
-			"Error Deleting Todo",
-			"Could not delete todo, unexpected error: "+err.Error(),
+			"Todo Changed Since Last Read",
+			"Todo ID "+state.ID.ValueString()+" was modified after Terraform last read it, so it was not deleted. "+
+				"Refresh the state, for example with terraform apply -refresh-only, review the changes and destroy it again.",
 		)
 		return
 	}
+	if err != nil {
+		resp.Diagnostics.Append(r.diagnostics.MapError(ErrorContext{Operation: "delete", Resource: "todo", ID: state.ID.ValueString()}, err)...)
+		return
+	}
 
 	tflog.Info(ctx, "Deleted todo", map[string]any{"id": state.ID.ValueString()})
 }
 
 // ImportState imports the resource into Terraform state.
 func (r *todoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Reject malformed ids here rather than with a failed read
+	if !client.IsValidUUID(req.ID) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Todos are imported by id, which must be a UUID such as 3f2504e0-4f89-41d3-9a0c-0305e82c3301, got: %q", req.ID),
+		)
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
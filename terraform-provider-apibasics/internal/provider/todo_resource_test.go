@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAddServerWarnings(t *testing.T) {
+	var diags diag.Diagnostics
+	addServerWarnings(&diags, &client.Todo{ID: "todo-1", Warnings: []string{"title is very long", "description is empty"}})
+
+	if diags.HasError() {
+		t.Fatalf("diagnostics = %v, want warnings only", diags)
+	}
+	warnings := diags.Warnings()
+	if len(warnings) != 2 {
+		t.Fatalf("got %d warnings, want 2", len(warnings))
+	}
+	if detail := warnings[0].Detail(); !strings.Contains(detail, "todo-1") || !strings.Contains(detail, "title is very long") {
+		t.Errorf("warning detail = %q, want the todo id and server message", detail)
+	}
+}
+
+func TestUpdateWithNothingChangedMakesNoAPICalls(t *testing.T) {
+	r := newTestTodoResource(t, nil)
+	s := todoResourceSchema(t, r)
+
+	state := storedTodo()
+	// Terraform plans computed attributes as unknown whenever it calls Update
+	plan := state
+	plan.UpdatedAt = types.StringUnknown()
+	plan.ModifiedBy = types.StringUnknown()
+	plan.RawJSON = types.StringUnknown()
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: s, Raw: todoObject(t, r, plan)},
+		State: tfsdk.State{Schema: s, Raw: todoObject(t, r, state)},
+	}
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: req.Plan.Raw}}
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update() diagnostics = %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.Equal(req.State.Raw) {
+		t.Errorf("state = %v, want the prior state kept", resp.State.Raw)
+	}
+}
+
+func TestValidateConfigCompletedAt(t *testing.T) {
+	tests := []struct {
+		name        string
+		completed   types.Bool
+		completedAt types.String
+		wantError   string
+	}{
+		{name: "completed with time", completed: types.BoolValue(true), completedAt: types.StringValue("2024-05-01T12:00:00Z")},
+		{name: "no time", completed: types.BoolNull(), completedAt: types.StringNull()},
+		{name: "time without completion", completed: types.BoolNull(), completedAt: types.StringValue("2024-05-01T12:00:00Z"), wantError: "Completion Time Without Completion"},
+		{name: "time on an open todo", completed: types.BoolValue(false), completedAt: types.StringValue("2024-05-01T12:00:00Z"), wantError: "Completion Time Without Completion"},
+		{name: "malformed time", completed: types.BoolValue(true), completedAt: types.StringValue("May 1st"), wantError: "Invalid Completion Time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestTodoResource(t, nil)
+			config := storedTodo()
+			config.ID, config.UserID, config.CreatedAt, config.UpdatedAt = types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull()
+			config.Completed, config.CompletedAt = tt.completed, tt.completedAt
+
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: todoResourceSchema(t, r), Raw: todoObject(t, r, config)}}
+			var resp resource.ValidateConfigResponse
+			r.ValidateConfig(context.Background(), req, &resp)
+
+			if tt.wantError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("diagnostics = %v, want none", resp.Diagnostics)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary() != tt.wantError {
+				t.Errorf("diagnostics = %v, want a single %q error", resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestReadToleratesTransientErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerate  bool
+		status    int
+		wantError bool
+	}{
+		{name: "tolerated outage", tolerate: true, status: http.StatusServiceUnavailable},
+		{name: "outage without tolerance", tolerate: false, status: http.StatusServiceUnavailable, wantError: true},
+		{name: "client error is not tolerated", tolerate: true, status: http.StatusBadRequest, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestTodoResource(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			})
+			r.tolerateReadErrors = tt.tolerate
+			s := todoResourceSchema(t, r)
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: todoObject(t, r, storedTodo())}}
+			resp := &resource.ReadResponse{State: req.State}
+			r.Read(context.Background(), req, resp)
+
+			if tt.wantError {
+				if !resp.Diagnostics.HasError() {
+					t.Errorf("diagnostics = %v, want an error", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+				t.Fatalf("diagnostics = %v, want a single warning", resp.Diagnostics)
+			}
+			if !resp.State.Raw.Equal(req.State.Raw) {
+				t.Errorf("state = %v, want the prior state kept", resp.State.Raw)
+			}
+		})
+	}
+}
+
+func TestRawJSONValue(t *testing.T) {
+	if got := rawJSONValue(&client.Todo{}); !got.IsNull() {
+		t.Errorf("rawJSONValue() without a response = %v, want null", got)
+	}
+
+	raw := `{"id":"` + testTodoID + `","estimate":{"hours":2}}`
+	if got := rawJSONValue(&client.Todo{Raw: []byte(raw)}); got.ValueString() != raw {
+		t.Errorf("rawJSONValue() = %v, want %s", got, raw)
+	}
+}
+
+func TestModifyPlanDropsUpdatesTodosEqualIgnores(t *testing.T) {
+	tests := []struct {
+		name       string
+		edit       func(m *todoResourceModel)
+		wantUpdate bool
+	}{
+		{name: "same instant in another offset", edit: func(m *todoResourceModel) { m.CompletedAt = types.StringValue("2024-05-01T14:00:00+02:00") }},
+		{name: "new title", edit: func(m *todoResourceModel) { m.Title = types.StringValue("Write more tests") }, wantUpdate: true},
+		{name: "different instant", edit: func(m *todoResourceModel) { m.CompletedAt = types.StringValue("2024-05-01T13:00:00Z") }, wantUpdate: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestTodoResource(t, nil)
+			s := todoResourceSchema(t, r)
+
+			state := storedTodo()
+			plan := state
+			tt.edit(&plan)
+			plan.UpdatedAt = types.StringUnknown()
+			plan.ModifiedBy = types.StringUnknown()
+			plan.RawJSON = types.StringUnknown()
+			config := plan
+			config.ID, config.UserID, config.CreatedAt, config.UpdatedAt = types.StringNull(), types.StringNull(), types.StringNull(), types.StringNull()
+			config.ModifiedBy, config.RawJSON = types.StringNull(), types.StringNull()
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: todoObject(t, r, config)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: todoObject(t, r, plan)},
+				State:  tfsdk.State{Schema: s, Raw: todoObject(t, r, state)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ModifyPlan() diagnostics = %v", resp.Diagnostics)
+			}
+			if update := !resp.Plan.Raw.Equal(req.State.Raw); update != tt.wantUpdate {
+				t.Errorf("update planned = %t, want %t", update, tt.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestCreateFailsWhenAPIDropsFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		workspace string
+		metadata  types.Map
+		parentID  types.String
+		wantError string
+	}{
+		{name: "metadata", metadata: storedTodo().Metadata, parentID: types.StringNull(), wantError: "Metadata Not Supported"},
+		{name: "workspace stamp", workspace: "prod", metadata: types.MapNull(types.StringType), parentID: types.StringNull(), wantError: "Metadata Not Supported"},
+		{name: "parent", metadata: types.MapNull(types.StringType), parentID: types.StringValue("1c6b8d0f-2e3f-4a5b-9c7d-8e0f1a2b3c4d"), wantError: "Parent Not Supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			r := newTestTodoResource(t, func(w http.ResponseWriter, req *http.Request) {
+				switch req.Method {
+				case http.MethodPost:
+					// Like the bundled API, store only the title and description
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(client.Todo{ID: testTodoID, Title: "Write tests"})
+				case http.MethodDelete:
+					deleted = true
+					w.WriteHeader(http.StatusOK)
+				default:
+					t.Errorf("unexpected API request %s %s", req.Method, req.URL.Path)
+				}
+			})
+			r.workspace.id = tt.workspace
+			s := todoResourceSchema(t, r)
+
+			plan := storedTodo()
+			plan.ID, plan.UserID, plan.CreatedAt, plan.UpdatedAt = types.StringUnknown(), types.StringUnknown(), types.StringUnknown(), types.StringUnknown()
+			plan.ModifiedBy, plan.RawJSON = types.StringUnknown(), types.StringUnknown()
+			plan.Completed, plan.CompletedAt = types.BoolValue(false), types.StringNull()
+			plan.Metadata, plan.ParentID = tt.metadata, tt.parentID
+
+			req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: todoObject(t, r, plan)}}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)}}
+			r.Create(context.Background(), req, resp)
+
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary() != tt.wantError {
+				t.Fatalf("diagnostics = %v, want a single %q error", resp.Diagnostics, tt.wantError)
+			}
+			if !deleted {
+				t.Error("the created todo was not deleted")
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Errorf("state = %v, want none saved", resp.State.Raw)
+			}
+		})
+	}
+}
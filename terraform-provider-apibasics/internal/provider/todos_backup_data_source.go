@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todosBackupDataSource{}
+	_ datasource.DataSourceWithConfigure = &todosBackupDataSource{}
+)
+
+// NewTodosBackupDataSource is a helper function to simplify the provider implementation.
+func NewTodosBackupDataSource() datasource.DataSource {
+	return &todosBackupDataSource{}
+}
+
+// todosBackupDataSource is the data source implementation.
+type todosBackupDataSource struct {
+	client *client.Client
+}
+
+// todosBackupDataSourceModel maps the data source schema data.
+type todosBackupDataSourceModel struct {
+	Path   types.String `tfsdk:"path"`
+	Count  types.Int64  `tfsdk:"count"`
+	SHA256 types.String `tfsdk:"sha256"`
+}
+
+// Metadata returns the data source type name.
+func (d *todosBackupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todos_backup"
+}
+
+// Schema defines the schema for the data source.
+func (d *todosBackupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Writes every todo to a local file as newline-delimited JSON, streaming page by page so large accounts can be backed up. " +
+			"The file is rewritten each time the data source is read.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "Local file to write the backup to. It is replaced only once the export has completed.",
+				Required:    true,
+			},
+			"count": schema.Int64Attribute{
+				Description: "Number of todos written.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "Hex SHA-256 of the backup file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todosBackupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todosBackupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todosBackupDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	count, sum, err := d.writeBackup(ctx, state.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Backing Up Todos",
+			"Could not write todos to "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Count = types.Int64Value(int64(count))
+	state.SHA256 = types.StringValue(sum)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Backed up todos", map[string]any{"path": state.Path.ValueString(), "count": count})
+}
+
+// writeBackup exports the todos to a temporary file next to path and moves
+// it into place once complete, so a failed export never leaves a truncated
+// backup behind. It returns the number of todos and the file's checksum.
+func (d *todosBackupDataSource) writeBackup(ctx context.Context, path string) (int, string, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return 0, "", err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	buffered := bufio.NewWriter(io.MultiWriter(file, hash))
+
+	count, err := d.client.ExportAllTodos(ctx, buffered)
+	if err != nil {
+		return 0, "", err
+	}
+	if err := buffered.Flush(); err != nil {
+		return 0, "", err
+	}
+	if err := file.Close(); err != nil {
+		return 0, "", err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return 0, "", err
+	}
+
+	return count, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todosBulkPlanDataSource{}
+	_ datasource.DataSourceWithConfigure = &todosBulkPlanDataSource{}
+)
+
+// NewTodosBulkPlanDataSource is a helper function to simplify the provider implementation.
+func NewTodosBulkPlanDataSource() datasource.DataSource {
+	return &todosBulkPlanDataSource{}
+}
+
+// todosBulkPlanDataSource is the data source implementation.
+type todosBulkPlanDataSource struct {
+	client             *client.Client
+	descriptionAffixes descriptionAffixes
+	workspace          workspaceOwnership
+}
+
+// todosBulkPlanDataSourceModel maps the data source schema data.
+type todosBulkPlanDataSourceModel struct {
+	Todos   []bulkPlanTodoModel   `tfsdk:"todos"`
+	Summary types.String          `tfsdk:"summary"`
+	Empty   types.Bool            `tfsdk:"empty"`
+	Create  []types.String        `tfsdk:"create"`
+	Update  []bulkPlanUpdateModel `tfsdk:"update"`
+	Delete  []types.String        `tfsdk:"delete"`
+}
+
+// bulkPlanTodoModel maps one desired todo.
+type bulkPlanTodoModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+	CompletedAt types.String `tfsdk:"completed_at"`
+	ParentID    types.String `tfsdk:"parent_id"`
+	Metadata    types.Map    `tfsdk:"metadata"`
+}
+
+// bulkPlanUpdateModel maps one planned update of an existing todo.
+type bulkPlanUpdateModel struct {
+	ID     types.String   `tfsdk:"id"`
+	Title  types.String   `tfsdk:"title"`
+	Fields []types.String `tfsdk:"fields"`
+}
+
+// Metadata returns the data source type name.
+func (d *todosBulkPlanDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todos_bulk_plan"
+}
+
+// Schema defines the schema for the data source.
+func (d *todosBulkPlanDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Previews the todos that would be created, updated and deleted to make the account match a desired list. Nothing is changed.",
+		Attributes: map[string]schema.Attribute{
+			"todos": schema.ListNestedAttribute{
+				Description: "The desired todos. One with an id is matched to that todo; one without is matched to an existing todo with the same title and parent.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "UUID of an existing todo to match.",
+							Optional:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Title of the todo.",
+							Required:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the todo.",
+							Optional:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "Whether the todo is completed.",
+							Optional:    true,
+						},
+						"completed_at": schema.StringAttribute{
+							Description: "RFC 3339 time the todo was completed. Only compared when the existing todo has one.",
+							Optional:    true,
+						},
+						"parent_id": schema.StringAttribute{
+							Description: "UUID of the parent todo.",
+							Optional:    true,
+						},
+						"metadata": schema.MapAttribute{
+							Description: "Map of key/value pairs attached to the todo.",
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"summary": schema.StringAttribute{
+				Description: "The counts of planned changes, such as \"1 to create, 0 to update, 2 to delete\".",
+				Computed:    true,
+			},
+			"empty": schema.BoolAttribute{
+				Description: "Whether the account already matches the desired todos.",
+				Computed:    true,
+			},
+			"create": schema.ListAttribute{
+				Description: "Titles of the desired todos that would be created.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"update": schema.ListNestedAttribute{
+				Description: "The existing todos that would be updated.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "UUID of the todo.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Current title of the todo.",
+							Computed:    true,
+						},
+						"fields": schema.ListAttribute{
+							Description: "API names of the fields that would change.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"delete": schema.ListAttribute{
+				Description: "UUIDs of the existing todos that would be deleted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todosBulkPlanDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+	d.descriptionAffixes = data.descriptionAffixes
+	d.workspace = data.workspace
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todosBulkPlanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todosBulkPlanDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Desired todos carry the affixes and workspace stamp the resource
+	// would write, so they compare equal to todos it already manages
+	desired := make([]client.Todo, 0, len(state.Todos))
+	for _, item := range state.Todos {
+		todo := client.Todo{
+			ID:          item.ID.ValueString(),
+			Title:       item.Title.ValueString(),
+			Description: d.descriptionAffixes.apply(item.Description.ValueString()),
+			Completed:   item.Completed.ValueBool(),
+			CompletedAt: item.CompletedAt.ValueString(),
+			ParentID:    item.ParentID.ValueString(),
+		}
+		if !item.Metadata.IsNull() {
+			resp.Diagnostics.Append(item.Metadata.ElementsAs(ctx, &todo.Metadata, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		todo.Metadata = d.workspace.stamp(todo.Metadata)
+		desired = append(desired, todo)
+	}
+
+	plan, err := d.client.PlanBulk(ctx, desired)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Planning Bulk Todo Changes",
+			"Could not plan bulk todo changes: "+err.Error(),
+		)
+		return
+	}
+
+	state.Summary = types.StringValue(plan.Summary())
+	state.Empty = types.BoolValue(plan.Empty())
+
+	state.Create = make([]types.String, 0, len(plan.Create))
+	for _, todo := range plan.Create {
+		state.Create = append(state.Create, types.StringValue(todo.Title))
+	}
+
+	state.Update = make([]bulkPlanUpdateModel, 0, len(plan.Update))
+	for _, update := range plan.Update {
+		fields := make([]types.String, 0, len(update.Fields))
+		for _, field := range update.Fields {
+			fields = append(fields, types.StringValue(field))
+		}
+		state.Update = append(state.Update, bulkPlanUpdateModel{
+			ID:     types.StringValue(update.Current.ID),
+			Title:  types.StringValue(update.Current.Title),
+			Fields: fields,
+		})
+	}
+
+	state.Delete = make([]types.String, 0, len(plan.Delete))
+	for _, todo := range plan.Delete {
+		state.Delete = append(state.Delete, types.StringValue(todo.ID))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Planned bulk todo changes", map[string]any{"summary": plan.Summary()})
+}
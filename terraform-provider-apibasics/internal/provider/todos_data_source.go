@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todosDataSource{}
+	_ datasource.DataSourceWithConfigure = &todosDataSource{}
+)
+
+// NewTodosDataSource is a helper function to simplify the provider implementation.
+func NewTodosDataSource() datasource.DataSource {
+	return &todosDataSource{}
+}
+
+// todosDataSource is the data source implementation.
+type todosDataSource struct {
+	client *client.Client
+}
+
+// todosDataSourceModel maps the data source schema data.
+type todosDataSourceModel struct {
+	ParallelFetch types.Bool          `tfsdk:"parallel_fetch"`
+	PageSize      types.Int64         `tfsdk:"page_size"`
+	ModifiedBy    types.String        `tfsdk:"modified_by"`
+	Sort          []todoSortModel     `tfsdk:"sort"`
+	CreatedAfter  types.String        `tfsdk:"created_after"`
+	CreatedBefore types.String        `tfsdk:"created_before"`
+	Query         types.String        `tfsdk:"query"`
+	Timeout       types.String        `tfsdk:"timeout"`
+	AllowPartial  types.Bool          `tfsdk:"allow_partial_list"`
+	Partial       types.Bool          `tfsdk:"partial"`
+	Todos         []todoListItemModel `tfsdk:"todos"`
+}
+
+// todoSortModel maps one sort key of the todos list.
+type todoSortModel struct {
+	Field     types.String `tfsdk:"field"`
+	Direction types.String `tfsdk:"direction"`
+}
+
+// todoSortFields maps sortable attribute names to API field names.
+var todoSortFields = map[string]string{
+	"title":      "title",
+	"completed":  "completed",
+	"created_at": "createdAt",
+	"updated_at": "updatedAt",
+}
+
+// todoListItemModel maps a single todo in the todos list.
+type todoListItemModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+	UserID      types.String `tfsdk:"user_id"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+	ParentID    types.String `tfsdk:"parent_id"`
+	ModifiedBy  types.String `tfsdk:"modified_by"`
+	RawJSON     types.String `tfsdk:"raw_json"`
+}
+
+// newTodoListItem converts an API todo into a list item.
+func newTodoListItem(todo client.Todo) todoListItemModel {
+	item := todoListItemModel{
+		ID:          types.StringValue(todo.ID),
+		Title:       types.StringValue(todo.Title),
+		Description: types.StringValue(todo.Description),
+		Completed:   types.BoolValue(todo.Completed),
+		UserID:      types.StringValue(todo.UserID),
+		CreatedAt:   types.StringValue(todo.CreatedAt),
+		UpdatedAt:   types.StringValue(todo.UpdatedAt),
+		ParentID:    types.StringNull(),
+		ModifiedBy:  types.StringNull(),
+		RawJSON:     rawJSONValue(&todo),
+	}
+	if todo.ParentID != "" {
+		item.ParentID = types.StringValue(todo.ParentID)
+	}
+	if todo.ModifiedBy != "" {
+		item.ModifiedBy = types.StringValue(todo.ModifiedBy)
+	}
+	return item
+}
+
+// todoListItemAttributes returns the nested schema of a todo list item.
+func todoListItemAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "UUID of the todo.",
+			Computed:    true,
+		},
+		"title": schema.StringAttribute{
+			Description: "Title of the todo.",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "Description of the todo.",
+			Computed:    true,
+		},
+		"completed": schema.BoolAttribute{
+			Description: "Whether the todo is completed.",
+			Computed:    true,
+		},
+		"user_id": schema.StringAttribute{
+			Description: "UUID of the user who owns this todo.",
+			Computed:    true,
+		},
+		"created_at": schema.StringAttribute{
+			Description: "Timestamp when the todo was created.",
+			Computed:    true,
+		},
+		"updated_at": schema.StringAttribute{
+			Description: "Timestamp when the todo was last updated.",
+			Computed:    true,
+		},
+		"parent_id": schema.StringAttribute{
+			Description: "UUID of the parent todo, if this todo is a subtask.",
+			Computed:    true,
+		},
+		"modified_by": schema.StringAttribute{
+			Description: "UUID of the user who last modified the todo, when the API tracks it.",
+			Computed:    true,
+		},
+		"raw_json": schema.StringAttribute{
+			Description: rawJSONDescription,
+			Computed:    true,
+		},
+	}
+}
+
+// Metadata returns the data source type name.
+func (d *todosDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todos"
+}
+
+// Schema defines the schema for the data source.
+func (d *todosDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all todos owned by the authenticated user.",
+		Attributes: map[string]schema.Attribute{
+			"parallel_fetch": schema.BoolAttribute{
+				Description: "Fetch pages concurrently when the API reports the total number of todos. Falls back to sequential paging otherwise. Defaults to false.",
+				Optional:    true,
+			},
+			"page_size": schema.Int64Attribute{
+				Description: "Number of todos to request per page. The server may cap it, in which case a warning is logged and paging continues at the server's size. Defaults to the server's page size.",
+				Optional:    true,
+			},
+			"modified_by": schema.StringAttribute{
+				Description: "Only return todos last modified by the user with this UUID. Requires an API that tracks who modified todos.",
+				Optional:    true,
+			},
+			"created_after": schema.StringAttribute{
+				Description: "Only return todos created after this RFC 3339 timestamp.",
+				Optional:    true,
+			},
+			"created_before": schema.StringAttribute{
+				Description: "Only return todos created before this RFC 3339 timestamp. Must be later than created_after.",
+				Optional:    true,
+			},
+			"query": schema.StringAttribute{
+				Description: "Search query sent to the API, e.g. `completed:false priority:high \"release notes\"`. Terms are field:value or free text, quoted to include spaces, and a leading - excludes matches. " +
+					"The syntax is checked before sending; which fields can be searched depends on the API, and APIs without search return every todo.",
+				Optional: true,
+			},
+			"sort": schema.ListNestedAttribute{
+				Description: "Sort the todos by these keys in order; later keys break ties in earlier ones. Defaults to the server's order.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Description: "Attribute to sort by: title, completed, created_at or updated_at.",
+							Required:    true,
+						},
+						"direction": schema.StringAttribute{
+							Description: "Sort direction, asc or desc. Defaults to asc.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long listing may take before failing (e.g. \"2m\"). Unlimited when unset.",
+				Optional:    true,
+			},
+			"allow_partial_list": schema.BoolAttribute{
+				Description: "When timeout is about to pass, return the todos listed so far with a warning instead of failing. " +
+					"With parallel_fetch, the pages that arrived in time are returned. Defaults to false.",
+				Optional: true,
+			},
+			"partial": schema.BoolAttribute{
+				Description: "Whether todos is incomplete because listing was cut short by timeout, with allow_partial_list set.",
+				Computed:    true,
+			},
+			"todos": schema.ListNestedAttribute{
+				Description: "The todos.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: todoListItemAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todosDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todosDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state todosDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var opts client.ListOptions
+	if !state.PageSize.IsNull() {
+		if state.PageSize.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("page_size"),
+				"Invalid Page Size",
+				fmt.Sprintf("page_size must be at least 1, got %d.", state.PageSize.ValueInt64()),
+			)
+		}
+		opts.PageSize = int(state.PageSize.ValueInt64())
+	}
+	for i, key := range state.Sort {
+		field, ok := todoSortFields[key.Field.ValueString()]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sort").AtListIndex(i).AtName("field"),
+				"Invalid Sort Field",
+				"Todos can be sorted by title, completed, created_at or updated_at, got: "+key.Field.ValueString(),
+			)
+			continue
+		}
+		direction := client.SortDirection(key.Direction.ValueString())
+		switch direction {
+		case "", client.SortAscending, client.SortDescending:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sort").AtListIndex(i).AtName("direction"),
+				"Invalid Sort Direction",
+				"Sort direction must be asc or desc, got: "+key.Direction.ValueString(),
+			)
+			continue
+		}
+		opts.SortBy = append(opts.SortBy, client.SortField{Field: field, Direction: direction})
+	}
+	if !state.Query.IsNull() {
+		opts.Query = state.Query.ValueString()
+		if _, err := client.ParseQuery(opts.Query); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("query"),
+				"Invalid Query",
+				"The query could not be parsed: "+err.Error(),
+			)
+		}
+	}
+	opts.CreatedAfter = parseTimestamp(state.CreatedAfter, "created_after", &resp.Diagnostics)
+	opts.CreatedBefore = parseTimestamp(state.CreatedBefore, "created_before", &resp.Diagnostics)
+	if !opts.CreatedAfter.IsZero() && !opts.CreatedBefore.IsZero() && !opts.CreatedAfter.Before(opts.CreatedBefore) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("created_before"),
+			"Invalid Date Range",
+			"created_before must be later than created_after.",
+		)
+	}
+	opts.AllowPartial = state.AllowPartial.ValueBool()
+	timeout := parseDuration(state.Timeout, "timeout", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var todos []client.Todo
+	var err error
+	switch {
+	case !state.ModifiedBy.IsNull():
+		todos, err = d.client.ListTodosByModifier(ctx, state.ModifiedBy.ValueString(), opts)
+	case state.ParallelFetch.ValueBool():
+		todos, err = d.client.ListTodosParallel(ctx, opts)
+	default:
+		todos, err = d.client.ListTodos(ctx, opts)
+	}
+	if errors.Is(err, client.ErrModifiedByUnsupported) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("modified_by"),
+			"Modifier Filter Not Supported",
+			"The API does not report which user modified todos, so they cannot be filtered by modified_by.",
+		)
+		return
+	}
+	if errors.Is(err, client.ErrQueryRejected) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query"),
+			"Query Rejected",
+			"The API did not accept the query: "+err.Error(),
+		)
+		return
+	}
+	state.Partial = types.BoolValue(false)
+	if errors.Is(err, client.ErrPartialList) {
+		resp.Diagnostics.AddWarning(
+			"Todo List Incomplete",
+			fmt.Sprintf("Listing todos did not finish within %s, so only the %d todos listed so far are returned: %s", timeout, len(todos), err.Error()),
+		)
+		state.Partial = types.BoolValue(true)
+		err = nil
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Todos",
+			"Could not list todos, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Todos = make([]todoListItemModel, 0, len(todos))
+	for _, todo := range todos {
+		state.Todos = append(state.Todos, newTodoListItem(todo))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Listed todos", map[string]any{"count": len(todos)})
+}
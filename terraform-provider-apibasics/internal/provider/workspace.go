@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// managedByMetadataKey is the metadata key stamped with the provider's
+// workspace_id on todos it creates.
+const managedByMetadataKey = "managed_by"
+
+// workspaceOwnership records which workspace manages the todos this provider
+// instance writes, so todos managed by another configuration can be spotted.
+type workspaceOwnership struct {
+	// id is the configured workspace_id; empty disables ownership tracking
+	id string
+
+	// mismatchIsError reports mismatches as errors rather than warnings
+	mismatchIsError bool
+}
+
+// stamp returns a copy of metadata carrying the workspace id.
+func (w workspaceOwnership) stamp(metadata map[string]string) map[string]string {
+	if w.id == "" {
+		return metadata
+	}
+
+	stamped := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		stamped[k] = v
+	}
+	stamped[managedByMetadataKey] = w.id
+	return stamped
+}
+
+// hide returns a copy of todo without the managed_by stamp, so the stamp
+// never appears in state or produces a diff against the configuration.
+func (w workspaceOwnership) hide(todo *client.Todo) *client.Todo {
+	if w.id == "" {
+		return todo
+	}
+	if _, ok := todo.Metadata[managedByMetadataKey]; !ok {
+		return todo
+	}
+
+	visible := *todo
+	visible.Metadata = make(map[string]string, len(todo.Metadata))
+	for k, v := range todo.Metadata {
+		if k != managedByMetadataKey {
+			visible.Metadata[k] = v
+		}
+	}
+	return &visible
+}
+
+// check reports a todo stamped by a different workspace. Todos with no
+// stamp, such as ones created outside Terraform, are not reported.
+func (w workspaceOwnership) check(todo *client.Todo, diags *diag.Diagnostics) {
+	owner, ok := todo.Metadata[managedByMetadataKey]
+	if w.id == "" || !ok || owner == w.id {
+		return
+	}
+
+	summary := "Todo Managed by Another Workspace"
+	detail := fmt.Sprintf("Todo %s is marked as managed by workspace %q, but this provider is configured with workspace_id %q. "+
+		"Two configurations managing the same todo will keep overwriting each other's changes.", todo.ID, owner, w.id)
+	if w.mismatchIsError {
+		diags.AddError(summary, detail)
+		return
+	}
+	diags.AddWarning(summary, detail)
+}
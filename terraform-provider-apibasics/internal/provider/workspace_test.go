@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/api-basics/terraform-provider-apibasics/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestWorkspaceStampAndHide(t *testing.T) {
+	w := workspaceOwnership{id: "team-a"}
+	metadata := map[string]string{"owner": "ada"}
+
+	stamped := w.stamp(metadata)
+	if want := map[string]string{"owner": "ada", managedByMetadataKey: "team-a"}; !reflect.DeepEqual(stamped, want) {
+		t.Errorf("stamp() = %v, want %v", stamped, want)
+	}
+	if _, ok := metadata[managedByMetadataKey]; ok {
+		t.Error("stamp() modified the configured metadata")
+	}
+
+	visible := w.hide(&client.Todo{Metadata: stamped})
+	if !reflect.DeepEqual(visible.Metadata, metadata) {
+		t.Errorf("hide() metadata = %v, want %v", visible.Metadata, metadata)
+	}
+
+	if got := (workspaceOwnership{}).stamp(metadata); !reflect.DeepEqual(got, metadata) {
+		t.Errorf("stamp() without a workspace = %v, want the metadata unchanged", got)
+	}
+}
+
+func TestWorkspaceCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		workspace workspaceOwnership
+		metadata  map[string]string
+		warnings  int
+		errors    int
+	}{
+		{name: "match", workspace: workspaceOwnership{id: "team-a"}, metadata: map[string]string{managedByMetadataKey: "team-a"}},
+		{name: "unstamped", workspace: workspaceOwnership{id: "team-a"}, metadata: nil},
+		{name: "tracking disabled", workspace: workspaceOwnership{}, metadata: map[string]string{managedByMetadataKey: "team-b"}},
+		{name: "mismatch warns", workspace: workspaceOwnership{id: "team-a"}, metadata: map[string]string{managedByMetadataKey: "team-b"}, warnings: 1},
+		{name: "mismatch errors", workspace: workspaceOwnership{id: "team-a", mismatchIsError: true}, metadata: map[string]string{managedByMetadataKey: "team-b"}, errors: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			tt.workspace.check(&client.Todo{ID: "todo-1", Metadata: tt.metadata}, &diags)
+			if got := diags.WarningsCount(); got != tt.warnings {
+				t.Errorf("warnings = %d, want %d", got, tt.warnings)
+			}
+			if got := diags.ErrorsCount(); got != tt.errors {
+				t.Errorf("errors = %d, want %d", got, tt.errors)
+			}
+		})
+	}
+}